@@ -0,0 +1,802 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: fizzbuzz/v1/fizzbuzz.proto
+
+package fizzbuzzv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type HealthResponse_Status int32
+
+const (
+	HealthResponse_STATUS_UNSPECIFIED HealthResponse_Status = 0
+	HealthResponse_STATUS_SERVING     HealthResponse_Status = 1
+	HealthResponse_STATUS_NOT_SERVING HealthResponse_Status = 2
+)
+
+// Enum value maps for HealthResponse_Status.
+var (
+	HealthResponse_Status_name = map[int32]string{
+		0: "STATUS_UNSPECIFIED",
+		1: "STATUS_SERVING",
+		2: "STATUS_NOT_SERVING",
+	}
+	HealthResponse_Status_value = map[string]int32{
+		"STATUS_UNSPECIFIED": 0,
+		"STATUS_SERVING":     1,
+		"STATUS_NOT_SERVING": 2,
+	}
+)
+
+func (x HealthResponse_Status) Enum() *HealthResponse_Status {
+	p := new(HealthResponse_Status)
+	*p = x
+	return p
+}
+
+func (x HealthResponse_Status) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (HealthResponse_Status) Descriptor() protoreflect.EnumDescriptor {
+	return file_fizzbuzz_v1_fizzbuzz_proto_enumTypes[0].Descriptor()
+}
+
+func (HealthResponse_Status) Type() protoreflect.EnumType {
+	return &file_fizzbuzz_v1_fizzbuzz_proto_enumTypes[0]
+}
+
+func (x HealthResponse_Status) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use HealthResponse_Status.Descriptor instead.
+func (HealthResponse_Status) EnumDescriptor() ([]byte, []int) {
+	return file_fizzbuzz_v1_fizzbuzz_proto_rawDescGZIP(), []int{9, 0}
+}
+
+type FizzBuzzRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Int1  int64                  `protobuf:"varint,1,opt,name=int1,proto3" json:"int1,omitempty"`
+	Int2  int64                  `protobuf:"varint,2,opt,name=int2,proto3" json:"int2,omitempty"`
+	// Length of the sequence, replaced by end in range requests.
+	Limit int64  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Str1  string `protobuf:"bytes,4,opt,name=str1,proto3" json:"str1,omitempty"`
+	Str2  string `protobuf:"bytes,5,opt,name=str2,proto3" json:"str2,omitempty"`
+	// A range replaces the limit: from start (1 by default) to end, by step (1 by default, -1 when end is below start).
+	Start *int64 `protobuf:"varint,6,opt,name=start,proto3,oneof" json:"start,omitempty"`
+	End   *int64 `protobuf:"varint,7,opt,name=end,proto3,oneof" json:"end,omitempty"`
+	Step  *int64 `protobuf:"varint,8,opt,name=step,proto3,oneof" json:"step,omitempty"`
+	// decimal by default, hex, binary, roman, words, or a zero-padded width like 05.
+	NumberFormat  string `protobuf:"bytes,9,opt,name=number_format,json=numberFormat,proto3" json:"number_format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FizzBuzzRequest) Reset() {
+	*x = FizzBuzzRequest{}
+	mi := &file_fizzbuzz_v1_fizzbuzz_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FizzBuzzRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FizzBuzzRequest) ProtoMessage() {}
+
+func (x *FizzBuzzRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fizzbuzz_v1_fizzbuzz_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FizzBuzzRequest.ProtoReflect.Descriptor instead.
+func (*FizzBuzzRequest) Descriptor() ([]byte, []int) {
+	return file_fizzbuzz_v1_fizzbuzz_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FizzBuzzRequest) GetInt1() int64 {
+	if x != nil {
+		return x.Int1
+	}
+	return 0
+}
+
+func (x *FizzBuzzRequest) GetInt2() int64 {
+	if x != nil {
+		return x.Int2
+	}
+	return 0
+}
+
+func (x *FizzBuzzRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *FizzBuzzRequest) GetStr1() string {
+	if x != nil {
+		return x.Str1
+	}
+	return ""
+}
+
+func (x *FizzBuzzRequest) GetStr2() string {
+	if x != nil {
+		return x.Str2
+	}
+	return ""
+}
+
+func (x *FizzBuzzRequest) GetStart() int64 {
+	if x != nil && x.Start != nil {
+		return *x.Start
+	}
+	return 0
+}
+
+func (x *FizzBuzzRequest) GetEnd() int64 {
+	if x != nil && x.End != nil {
+		return *x.End
+	}
+	return 0
+}
+
+func (x *FizzBuzzRequest) GetStep() int64 {
+	if x != nil && x.Step != nil {
+		return *x.Step
+	}
+	return 0
+}
+
+func (x *FizzBuzzRequest) GetNumberFormat() string {
+	if x != nil {
+		return x.NumberFormat
+	}
+	return ""
+}
+
+type GenerateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Request       *FizzBuzzRequest       `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateRequest) Reset() {
+	*x = GenerateRequest{}
+	mi := &file_fizzbuzz_v1_fizzbuzz_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateRequest) ProtoMessage() {}
+
+func (x *GenerateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fizzbuzz_v1_fizzbuzz_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateRequest.ProtoReflect.Descriptor instead.
+func (*GenerateRequest) Descriptor() ([]byte, []int) {
+	return file_fizzbuzz_v1_fizzbuzz_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GenerateRequest) GetRequest() *FizzBuzzRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+type GenerateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        []string               `protobuf:"bytes,1,rep,name=result,proto3" json:"result,omitempty"`
+	DurationMs    int64                  `protobuf:"varint,2,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateResponse) Reset() {
+	*x = GenerateResponse{}
+	mi := &file_fizzbuzz_v1_fizzbuzz_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateResponse) ProtoMessage() {}
+
+func (x *GenerateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fizzbuzz_v1_fizzbuzz_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateResponse.ProtoReflect.Descriptor instead.
+func (*GenerateResponse) Descriptor() ([]byte, []int) {
+	return file_fizzbuzz_v1_fizzbuzz_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GenerateResponse) GetResult() []string {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *GenerateResponse) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+type GenerateStreamRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Request *FizzBuzzRequest       `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	// Number of values per chunk, defaults to 1000.
+	ChunkSize     int32 `protobuf:"varint,2,opt,name=chunk_size,json=chunkSize,proto3" json:"chunk_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateStreamRequest) Reset() {
+	*x = GenerateStreamRequest{}
+	mi := &file_fizzbuzz_v1_fizzbuzz_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateStreamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateStreamRequest) ProtoMessage() {}
+
+func (x *GenerateStreamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fizzbuzz_v1_fizzbuzz_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateStreamRequest.ProtoReflect.Descriptor instead.
+func (*GenerateStreamRequest) Descriptor() ([]byte, []int) {
+	return file_fizzbuzz_v1_fizzbuzz_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GenerateStreamRequest) GetRequest() *FizzBuzzRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *GenerateStreamRequest) GetChunkSize() int32 {
+	if x != nil {
+		return x.ChunkSize
+	}
+	return 0
+}
+
+type GenerateChunk struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Position in the sequence of the first value of the chunk, starting at 0.
+	Offset        int64    `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	Values        []string `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateChunk) Reset() {
+	*x = GenerateChunk{}
+	mi := &file_fizzbuzz_v1_fizzbuzz_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateChunk) ProtoMessage() {}
+
+func (x *GenerateChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_fizzbuzz_v1_fizzbuzz_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateChunk.ProtoReflect.Descriptor instead.
+func (*GenerateChunk) Descriptor() ([]byte, []int) {
+	return file_fizzbuzz_v1_fizzbuzz_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GenerateChunk) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *GenerateChunk) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type GetStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatsRequest) Reset() {
+	*x = GetStatsRequest{}
+	mi := &file_fizzbuzz_v1_fizzbuzz_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatsRequest) ProtoMessage() {}
+
+func (x *GetStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fizzbuzz_v1_fizzbuzz_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetStatsRequest) Descriptor() ([]byte, []int) {
+	return file_fizzbuzz_v1_fizzbuzz_proto_rawDescGZIP(), []int{5}
+}
+
+type GetStatsResponse struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	MostFrequentRequests []*FizzBuzzRequest     `protobuf:"bytes,1,rep,name=most_frequent_requests,json=mostFrequentRequests,proto3" json:"most_frequent_requests,omitempty"`
+	Count                int64                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	// Observed requests per most frequent request, unless stats are keyed by exact request.
+	Variants      []*RequestVariants `protobuf:"bytes,3,rep,name=variants,proto3" json:"variants,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatsResponse) Reset() {
+	*x = GetStatsResponse{}
+	mi := &file_fizzbuzz_v1_fizzbuzz_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatsResponse) ProtoMessage() {}
+
+func (x *GetStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fizzbuzz_v1_fizzbuzz_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetStatsResponse) Descriptor() ([]byte, []int) {
+	return file_fizzbuzz_v1_fizzbuzz_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetStatsResponse) GetMostFrequentRequests() []*FizzBuzzRequest {
+	if x != nil {
+		return x.MostFrequentRequests
+	}
+	return nil
+}
+
+func (x *GetStatsResponse) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetVariants() []*RequestVariants {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+type RequestVariants struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Requests      []*FizzBuzzRequest     `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestVariants) Reset() {
+	*x = RequestVariants{}
+	mi := &file_fizzbuzz_v1_fizzbuzz_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestVariants) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestVariants) ProtoMessage() {}
+
+func (x *RequestVariants) ProtoReflect() protoreflect.Message {
+	mi := &file_fizzbuzz_v1_fizzbuzz_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestVariants.ProtoReflect.Descriptor instead.
+func (*RequestVariants) Descriptor() ([]byte, []int) {
+	return file_fizzbuzz_v1_fizzbuzz_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RequestVariants) GetRequests() []*FizzBuzzRequest {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+type HealthRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HealthRequest) Reset() {
+	*x = HealthRequest{}
+	mi := &file_fizzbuzz_v1_fizzbuzz_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HealthRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthRequest) ProtoMessage() {}
+
+func (x *HealthRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fizzbuzz_v1_fizzbuzz_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthRequest.ProtoReflect.Descriptor instead.
+func (*HealthRequest) Descriptor() ([]byte, []int) {
+	return file_fizzbuzz_v1_fizzbuzz_proto_rawDescGZIP(), []int{8}
+}
+
+type HealthResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Status        HealthResponse_Status   `protobuf:"varint,1,opt,name=status,proto3,enum=fizzbuzz.v1.HealthResponse_Status" json:"status,omitempty"`
+	Checks        map[string]*CheckResult `protobuf:"bytes,2,rep,name=checks,proto3" json:"checks,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HealthResponse) Reset() {
+	*x = HealthResponse{}
+	mi := &file_fizzbuzz_v1_fizzbuzz_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HealthResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthResponse) ProtoMessage() {}
+
+func (x *HealthResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fizzbuzz_v1_fizzbuzz_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthResponse.ProtoReflect.Descriptor instead.
+func (*HealthResponse) Descriptor() ([]byte, []int) {
+	return file_fizzbuzz_v1_fizzbuzz_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *HealthResponse) GetStatus() HealthResponse_Status {
+	if x != nil {
+		return x.Status
+	}
+	return HealthResponse_STATUS_UNSPECIFIED
+}
+
+func (x *HealthResponse) GetChecks() map[string]*CheckResult {
+	if x != nil {
+		return x.Checks
+	}
+	return nil
+}
+
+type CheckResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckResult) Reset() {
+	*x = CheckResult{}
+	mi := &file_fizzbuzz_v1_fizzbuzz_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckResult) ProtoMessage() {}
+
+func (x *CheckResult) ProtoReflect() protoreflect.Message {
+	mi := &file_fizzbuzz_v1_fizzbuzz_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckResult.ProtoReflect.Descriptor instead.
+func (*CheckResult) Descriptor() ([]byte, []int) {
+	return file_fizzbuzz_v1_fizzbuzz_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CheckResult) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *CheckResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+var File_fizzbuzz_v1_fizzbuzz_proto protoreflect.FileDescriptor
+
+const file_fizzbuzz_v1_fizzbuzz_proto_rawDesc = "" +
+	"\n" +
+	"\x1afizzbuzz/v1/fizzbuzz.proto\x12\vfizzbuzz.v1\"\x82\x02\n" +
+	"\x0fFizzBuzzRequest\x12\x12\n" +
+	"\x04int1\x18\x01 \x01(\x03R\x04int1\x12\x12\n" +
+	"\x04int2\x18\x02 \x01(\x03R\x04int2\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x03R\x05limit\x12\x12\n" +
+	"\x04str1\x18\x04 \x01(\tR\x04str1\x12\x12\n" +
+	"\x04str2\x18\x05 \x01(\tR\x04str2\x12\x19\n" +
+	"\x05start\x18\x06 \x01(\x03H\x00R\x05start\x88\x01\x01\x12\x15\n" +
+	"\x03end\x18\a \x01(\x03H\x01R\x03end\x88\x01\x01\x12\x17\n" +
+	"\x04step\x18\b \x01(\x03H\x02R\x04step\x88\x01\x01\x12#\n" +
+	"\rnumber_format\x18\t \x01(\tR\fnumberFormatB\b\n" +
+	"\x06_startB\x06\n" +
+	"\x04_endB\a\n" +
+	"\x05_step\"I\n" +
+	"\x0fGenerateRequest\x126\n" +
+	"\arequest\x18\x01 \x01(\v2\x1c.fizzbuzz.v1.FizzBuzzRequestR\arequest\"K\n" +
+	"\x10GenerateResponse\x12\x16\n" +
+	"\x06result\x18\x01 \x03(\tR\x06result\x12\x1f\n" +
+	"\vduration_ms\x18\x02 \x01(\x03R\n" +
+	"durationMs\"n\n" +
+	"\x15GenerateStreamRequest\x126\n" +
+	"\arequest\x18\x01 \x01(\v2\x1c.fizzbuzz.v1.FizzBuzzRequestR\arequest\x12\x1d\n" +
+	"\n" +
+	"chunk_size\x18\x02 \x01(\x05R\tchunkSize\"?\n" +
+	"\rGenerateChunk\x12\x16\n" +
+	"\x06offset\x18\x01 \x01(\x03R\x06offset\x12\x16\n" +
+	"\x06values\x18\x02 \x03(\tR\x06values\"\x11\n" +
+	"\x0fGetStatsRequest\"\xb6\x01\n" +
+	"\x10GetStatsResponse\x12R\n" +
+	"\x16most_frequent_requests\x18\x01 \x03(\v2\x1c.fizzbuzz.v1.FizzBuzzRequestR\x14mostFrequentRequests\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\x128\n" +
+	"\bvariants\x18\x03 \x03(\v2\x1c.fizzbuzz.v1.RequestVariantsR\bvariants\"K\n" +
+	"\x0fRequestVariants\x128\n" +
+	"\brequests\x18\x01 \x03(\v2\x1c.fizzbuzz.v1.FizzBuzzRequestR\brequests\"\x0f\n" +
+	"\rHealthRequest\"\xb0\x02\n" +
+	"\x0eHealthResponse\x12:\n" +
+	"\x06status\x18\x01 \x01(\x0e2\".fizzbuzz.v1.HealthResponse.StatusR\x06status\x12?\n" +
+	"\x06checks\x18\x02 \x03(\v2'.fizzbuzz.v1.HealthResponse.ChecksEntryR\x06checks\x1aS\n" +
+	"\vChecksEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12.\n" +
+	"\x05value\x18\x02 \x01(\v2\x18.fizzbuzz.v1.CheckResultR\x05value:\x028\x01\"L\n" +
+	"\x06Status\x12\x16\n" +
+	"\x12STATUS_UNSPECIFIED\x10\x00\x12\x12\n" +
+	"\x0eSTATUS_SERVING\x10\x01\x12\x16\n" +
+	"\x12STATUS_NOT_SERVING\x10\x02\"3\n" +
+	"\vCheckResult\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\bR\x02ok\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error2\xba\x02\n" +
+	"\x0fFizzBuzzService\x12G\n" +
+	"\bGenerate\x12\x1c.fizzbuzz.v1.GenerateRequest\x1a\x1d.fizzbuzz.v1.GenerateResponse\x12R\n" +
+	"\x0eGenerateStream\x12\".fizzbuzz.v1.GenerateStreamRequest\x1a\x1a.fizzbuzz.v1.GenerateChunk0\x01\x12G\n" +
+	"\bGetStats\x12\x1c.fizzbuzz.v1.GetStatsRequest\x1a\x1d.fizzbuzz.v1.GetStatsResponse\x12A\n" +
+	"\x06Health\x12\x1a.fizzbuzz.v1.HealthRequest\x1a\x1b.fizzbuzz.v1.HealthResponseB/Z-fizzbuzz-api/api/proto/fizzbuzz/v1;fizzbuzzv1b\x06proto3"
+
+var (
+	file_fizzbuzz_v1_fizzbuzz_proto_rawDescOnce sync.Once
+	file_fizzbuzz_v1_fizzbuzz_proto_rawDescData []byte
+)
+
+func file_fizzbuzz_v1_fizzbuzz_proto_rawDescGZIP() []byte {
+	file_fizzbuzz_v1_fizzbuzz_proto_rawDescOnce.Do(func() {
+		file_fizzbuzz_v1_fizzbuzz_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_fizzbuzz_v1_fizzbuzz_proto_rawDesc), len(file_fizzbuzz_v1_fizzbuzz_proto_rawDesc)))
+	})
+	return file_fizzbuzz_v1_fizzbuzz_proto_rawDescData
+}
+
+var file_fizzbuzz_v1_fizzbuzz_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_fizzbuzz_v1_fizzbuzz_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_fizzbuzz_v1_fizzbuzz_proto_goTypes = []any{
+	(HealthResponse_Status)(0),    // 0: fizzbuzz.v1.HealthResponse.Status
+	(*FizzBuzzRequest)(nil),       // 1: fizzbuzz.v1.FizzBuzzRequest
+	(*GenerateRequest)(nil),       // 2: fizzbuzz.v1.GenerateRequest
+	(*GenerateResponse)(nil),      // 3: fizzbuzz.v1.GenerateResponse
+	(*GenerateStreamRequest)(nil), // 4: fizzbuzz.v1.GenerateStreamRequest
+	(*GenerateChunk)(nil),         // 5: fizzbuzz.v1.GenerateChunk
+	(*GetStatsRequest)(nil),       // 6: fizzbuzz.v1.GetStatsRequest
+	(*GetStatsResponse)(nil),      // 7: fizzbuzz.v1.GetStatsResponse
+	(*RequestVariants)(nil),       // 8: fizzbuzz.v1.RequestVariants
+	(*HealthRequest)(nil),         // 9: fizzbuzz.v1.HealthRequest
+	(*HealthResponse)(nil),        // 10: fizzbuzz.v1.HealthResponse
+	(*CheckResult)(nil),           // 11: fizzbuzz.v1.CheckResult
+	nil,                           // 12: fizzbuzz.v1.HealthResponse.ChecksEntry
+}
+var file_fizzbuzz_v1_fizzbuzz_proto_depIdxs = []int32{
+	1,  // 0: fizzbuzz.v1.GenerateRequest.request:type_name -> fizzbuzz.v1.FizzBuzzRequest
+	1,  // 1: fizzbuzz.v1.GenerateStreamRequest.request:type_name -> fizzbuzz.v1.FizzBuzzRequest
+	1,  // 2: fizzbuzz.v1.GetStatsResponse.most_frequent_requests:type_name -> fizzbuzz.v1.FizzBuzzRequest
+	8,  // 3: fizzbuzz.v1.GetStatsResponse.variants:type_name -> fizzbuzz.v1.RequestVariants
+	1,  // 4: fizzbuzz.v1.RequestVariants.requests:type_name -> fizzbuzz.v1.FizzBuzzRequest
+	0,  // 5: fizzbuzz.v1.HealthResponse.status:type_name -> fizzbuzz.v1.HealthResponse.Status
+	12, // 6: fizzbuzz.v1.HealthResponse.checks:type_name -> fizzbuzz.v1.HealthResponse.ChecksEntry
+	11, // 7: fizzbuzz.v1.HealthResponse.ChecksEntry.value:type_name -> fizzbuzz.v1.CheckResult
+	2,  // 8: fizzbuzz.v1.FizzBuzzService.Generate:input_type -> fizzbuzz.v1.GenerateRequest
+	4,  // 9: fizzbuzz.v1.FizzBuzzService.GenerateStream:input_type -> fizzbuzz.v1.GenerateStreamRequest
+	6,  // 10: fizzbuzz.v1.FizzBuzzService.GetStats:input_type -> fizzbuzz.v1.GetStatsRequest
+	9,  // 11: fizzbuzz.v1.FizzBuzzService.Health:input_type -> fizzbuzz.v1.HealthRequest
+	3,  // 12: fizzbuzz.v1.FizzBuzzService.Generate:output_type -> fizzbuzz.v1.GenerateResponse
+	5,  // 13: fizzbuzz.v1.FizzBuzzService.GenerateStream:output_type -> fizzbuzz.v1.GenerateChunk
+	7,  // 14: fizzbuzz.v1.FizzBuzzService.GetStats:output_type -> fizzbuzz.v1.GetStatsResponse
+	10, // 15: fizzbuzz.v1.FizzBuzzService.Health:output_type -> fizzbuzz.v1.HealthResponse
+	12, // [12:16] is the sub-list for method output_type
+	8,  // [8:12] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_fizzbuzz_v1_fizzbuzz_proto_init() }
+func file_fizzbuzz_v1_fizzbuzz_proto_init() {
+	if File_fizzbuzz_v1_fizzbuzz_proto != nil {
+		return
+	}
+	file_fizzbuzz_v1_fizzbuzz_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_fizzbuzz_v1_fizzbuzz_proto_rawDesc), len(file_fizzbuzz_v1_fizzbuzz_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_fizzbuzz_v1_fizzbuzz_proto_goTypes,
+		DependencyIndexes: file_fizzbuzz_v1_fizzbuzz_proto_depIdxs,
+		EnumInfos:         file_fizzbuzz_v1_fizzbuzz_proto_enumTypes,
+		MessageInfos:      file_fizzbuzz_v1_fizzbuzz_proto_msgTypes,
+	}.Build()
+	File_fizzbuzz_v1_fizzbuzz_proto = out.File
+	file_fizzbuzz_v1_fizzbuzz_proto_goTypes = nil
+	file_fizzbuzz_v1_fizzbuzz_proto_depIdxs = nil
+}
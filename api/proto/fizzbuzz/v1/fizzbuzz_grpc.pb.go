@@ -0,0 +1,251 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: fizzbuzz/v1/fizzbuzz.proto
+
+package fizzbuzzv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	FizzBuzzService_Generate_FullMethodName       = "/fizzbuzz.v1.FizzBuzzService/Generate"
+	FizzBuzzService_GenerateStream_FullMethodName = "/fizzbuzz.v1.FizzBuzzService/GenerateStream"
+	FizzBuzzService_GetStats_FullMethodName       = "/fizzbuzz.v1.FizzBuzzService/GetStats"
+	FizzBuzzService_Health_FullMethodName         = "/fizzbuzz.v1.FizzBuzzService/Health"
+)
+
+// FizzBuzzServiceClient is the client API for FizzBuzzService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// FizzBuzzService exposes the REST API over gRPC, with the same limits, stats and error semantics.
+type FizzBuzzServiceClient interface {
+	// Generate returns the whole sequence in a single message.
+	Generate(ctx context.Context, in *GenerateRequest, opts ...grpc.CallOption) (*GenerateResponse, error)
+	// GenerateStream returns the sequence in chunks, for sequences too large for a single message.
+	GenerateStream(ctx context.Context, in *GenerateStreamRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GenerateChunk], error)
+	// GetStats returns the most frequent requests.
+	GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*GetStatsResponse, error)
+	// Health reports the readiness of the server, like GET /readyz.
+	Health(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error)
+}
+
+type fizzBuzzServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFizzBuzzServiceClient(cc grpc.ClientConnInterface) FizzBuzzServiceClient {
+	return &fizzBuzzServiceClient{cc}
+}
+
+func (c *fizzBuzzServiceClient) Generate(ctx context.Context, in *GenerateRequest, opts ...grpc.CallOption) (*GenerateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GenerateResponse)
+	err := c.cc.Invoke(ctx, FizzBuzzService_Generate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fizzBuzzServiceClient) GenerateStream(ctx context.Context, in *GenerateStreamRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GenerateChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &FizzBuzzService_ServiceDesc.Streams[0], FizzBuzzService_GenerateStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GenerateStreamRequest, GenerateChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FizzBuzzService_GenerateStreamClient = grpc.ServerStreamingClient[GenerateChunk]
+
+func (c *fizzBuzzServiceClient) GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*GetStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetStatsResponse)
+	err := c.cc.Invoke(ctx, FizzBuzzService_GetStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fizzBuzzServiceClient) Health(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HealthResponse)
+	err := c.cc.Invoke(ctx, FizzBuzzService_Health_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FizzBuzzServiceServer is the server API for FizzBuzzService service.
+// All implementations must embed UnimplementedFizzBuzzServiceServer
+// for forward compatibility.
+//
+// FizzBuzzService exposes the REST API over gRPC, with the same limits, stats and error semantics.
+type FizzBuzzServiceServer interface {
+	// Generate returns the whole sequence in a single message.
+	Generate(context.Context, *GenerateRequest) (*GenerateResponse, error)
+	// GenerateStream returns the sequence in chunks, for sequences too large for a single message.
+	GenerateStream(*GenerateStreamRequest, grpc.ServerStreamingServer[GenerateChunk]) error
+	// GetStats returns the most frequent requests.
+	GetStats(context.Context, *GetStatsRequest) (*GetStatsResponse, error)
+	// Health reports the readiness of the server, like GET /readyz.
+	Health(context.Context, *HealthRequest) (*HealthResponse, error)
+	mustEmbedUnimplementedFizzBuzzServiceServer()
+}
+
+// UnimplementedFizzBuzzServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedFizzBuzzServiceServer struct{}
+
+func (UnimplementedFizzBuzzServiceServer) Generate(context.Context, *GenerateRequest) (*GenerateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Generate not implemented")
+}
+func (UnimplementedFizzBuzzServiceServer) GenerateStream(*GenerateStreamRequest, grpc.ServerStreamingServer[GenerateChunk]) error {
+	return status.Error(codes.Unimplemented, "method GenerateStream not implemented")
+}
+func (UnimplementedFizzBuzzServiceServer) GetStats(context.Context, *GetStatsRequest) (*GetStatsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetStats not implemented")
+}
+func (UnimplementedFizzBuzzServiceServer) Health(context.Context, *HealthRequest) (*HealthResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Health not implemented")
+}
+func (UnimplementedFizzBuzzServiceServer) mustEmbedUnimplementedFizzBuzzServiceServer() {}
+func (UnimplementedFizzBuzzServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeFizzBuzzServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FizzBuzzServiceServer will
+// result in compilation errors.
+type UnsafeFizzBuzzServiceServer interface {
+	mustEmbedUnimplementedFizzBuzzServiceServer()
+}
+
+func RegisterFizzBuzzServiceServer(s grpc.ServiceRegistrar, srv FizzBuzzServiceServer) {
+	// If the following call panics, it indicates UnimplementedFizzBuzzServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&FizzBuzzService_ServiceDesc, srv)
+}
+
+func _FizzBuzzService_Generate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FizzBuzzServiceServer).Generate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FizzBuzzService_Generate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FizzBuzzServiceServer).Generate(ctx, req.(*GenerateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FizzBuzzService_GenerateStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GenerateStreamRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FizzBuzzServiceServer).GenerateStream(m, &grpc.GenericServerStream[GenerateStreamRequest, GenerateChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FizzBuzzService_GenerateStreamServer = grpc.ServerStreamingServer[GenerateChunk]
+
+func _FizzBuzzService_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FizzBuzzServiceServer).GetStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FizzBuzzService_GetStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FizzBuzzServiceServer).GetStats(ctx, req.(*GetStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FizzBuzzService_Health_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HealthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FizzBuzzServiceServer).Health(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FizzBuzzService_Health_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FizzBuzzServiceServer).Health(ctx, req.(*HealthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FizzBuzzService_ServiceDesc is the grpc.ServiceDesc for FizzBuzzService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FizzBuzzService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "fizzbuzz.v1.FizzBuzzService",
+	HandlerType: (*FizzBuzzServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Generate",
+			Handler:    _FizzBuzzService_Generate_Handler,
+		},
+		{
+			MethodName: "GetStats",
+			Handler:    _FizzBuzzService_GetStats_Handler,
+		},
+		{
+			MethodName: "Health",
+			Handler:    _FizzBuzzService_Health_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GenerateStream",
+			Handler:       _FizzBuzzService_GenerateStream_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "fizzbuzz/v1/fizzbuzz.proto",
+}
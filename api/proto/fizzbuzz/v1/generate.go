@@ -0,0 +1,4 @@
+// Package fizzbuzzv1 holds the gRPC API definition and its generated Go code.
+package fizzbuzzv1
+
+//go:generate protoc --proto_path=../.. --go_out=../.. --go_opt=paths=source_relative --go-grpc_out=../.. --go-grpc_opt=paths=source_relative fizzbuzz/v1/fizzbuzz.proto
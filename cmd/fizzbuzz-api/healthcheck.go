@@ -0,0 +1,95 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fizzbuzz-api/internal/fizzbuzzapi/config"
+	"fizzbuzz-api/internal/fizzbuzzapi/logger"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+)
+
+// runHealthcheck queries the liveness probe of the server described by the config.
+// The distroless image has no shell nor curl, so the Docker HEALTHCHECK runs this command.
+func runHealthcheck(args []string) int {
+	fs := flag.NewFlagSet("healthcheck", flag.ContinueOnError)
+	ready := fs.Bool("ready", false, "query the readiness probe instead of the liveness probe")
+	clientCert := fs.String("client-cert", os.Getenv("FBAPI_HEALTHCHECK_CLIENT_CERT_FILE"), "client certificate presented to a server requiring mutual TLS (env FBAPI_HEALTHCHECK_CLIENT_CERT_FILE)")
+	clientKey := fs.String("client-key", os.Getenv("FBAPI_HEALTHCHECK_CLIENT_KEY_FILE"), "key of the client certificate (env FBAPI_HEALTHCHECK_CLIENT_KEY_FILE)")
+	caFile := fs.String("ca-file", os.Getenv("FBAPI_HEALTHCHECK_CA_FILE"), "CA bundle verifying the server certificate, which is not verified otherwise (env FBAPI_HEALTHCHECK_CA_FILE)")
+	cfg, _, code := loadConfig(fs, args, logger.NewNopLogger())
+	if cfg == nil {
+		return code
+	}
+
+	host := cfg.Host
+	if ip := net.ParseIP(host); host == "" || (ip != nil && ip.IsUnspecified()) {
+		host = "127.0.0.1"
+	}
+	path := "/livez"
+	if *ready {
+		path = "/readyz"
+	}
+	scheme := "http"
+	client := &http.Client{Timeout: 5 * time.Second}
+	if cfg.TLSEnabled() {
+		scheme = "https"
+		tlsConfig, err := healthcheckTLSConfig(cfg, *clientCert, *clientKey, *caFile)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "invalid TLS settings:", err)
+			return exitUsage
+		}
+		client.Transport = &http.Transport{TLSClientConfig: tlsConfig}
+	}
+	url := scheme + "://" + net.JoinHostPort(host, cfg.Port) + path
+
+	resp, err := client.Get(url)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "unhealthy:", err)
+		return exitError
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		fmt.Fprintln(os.Stderr, "unhealthy:", resp.Status)
+		return exitError
+	}
+	fmt.Println("healthy")
+	return exitOK
+}
+
+// healthcheckTLSConfig builds the client TLS config of the check. Without a CA bundle the server certificate is not
+// verified, since the check targets this very server on loopback and its certificate is not issued for that address.
+func healthcheckTLSConfig(cfg *config.Config, certFile, keyFile, caFile string) (*tls.Config, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: true}
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+		}
+		tlsConfig.InsecureSkipVerify = false
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, errors.New("failed to load CA bundle: no certificate found")
+		}
+	}
+
+	switch {
+	case certFile != "" && keyFile != "":
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	case certFile != "" || keyFile != "":
+		return nil, errors.New("--client-cert and --client-key must be set together")
+	case cfg.TLSClientCAFile != "" && cfg.TLSClientAuth == "require":
+		return nil, errors.New("the server requires a client certificate, set --client-cert and --client-key")
+	}
+	return tlsConfig, nil
+}
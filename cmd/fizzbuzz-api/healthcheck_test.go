@@ -0,0 +1,130 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"log"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCert struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+	keyPEM  []byte
+}
+
+// newTestCert issues a certificate for 127.0.0.1 signed by parent, or a self-signed CA when parent is nil
+func newTestCert(t *testing.T, cn string, parent *testCert, serial int64) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	signer, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage |= x509.KeyUsageCertSign
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return &testCert{
+		cert:    cert,
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+// writeCert writes the certificate and its key to dir, and returns their paths
+func (c *testCert) write(t *testing.T, dir, name string) (certFile, keyFile string) {
+	certFile, keyFile = filepath.Join(dir, name+".crt"), filepath.Join(dir, name+".key")
+	require.NoError(t, os.WriteFile(certFile, c.certPEM, 0o600))
+	require.NoError(t, os.WriteFile(keyFile, c.keyPEM, 0o600))
+	return certFile, keyFile
+}
+
+func Test_Healthcheck_MutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCert(t, "ca", nil, 1)
+	caFile, _ := ca.write(t, dir, "ca")
+	serverCert, serverKey := newTestCert(t, "server", ca, 2).write(t, dir, "server")
+	serverKeyPair, err := tls.LoadX509KeyPair(serverCert, serverKey)
+	require.NoError(t, err)
+	clientCert, clientKey := newTestCert(t, "client", ca, 3).write(t, dir, "client")
+
+	// The check only reads the TLS settings of the config, the test server stands for the real one
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/livez", r.URL.Path)
+		w.Write([]byte(`{"status":"alive"}`))
+	}))
+	server.TLS = &tls.Config{Certificates: []tls.Certificate{serverKeyPair}, ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: pool}
+	server.Config.ErrorLog = log.New(io.Discard, "", 0) // Rejected handshakes are expected
+	server.StartTLS()
+	defer server.Close()
+	_, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	require.NoError(t, err)
+
+	serverArgs := []string{"--host", "127.0.0.1", "--port", port, "--tls-cert-file", serverCert, "--tls-key-file", serverKey, "--tls-client-ca-file", caFile}
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"client certificate", []string{"--client-cert", clientCert, "--client-key", clientKey}, exitOK},
+		{"verified server", []string{"--client-cert", clientCert, "--client-key", clientKey, "--ca-file", caFile}, exitOK},
+		{"no client certificate", nil, exitUsage},
+		{"missing key", []string{"--client-cert", clientCert}, exitUsage},
+		{"unverified server", []string{"--client-cert", clientCert, "--client-key", clientKey, "--ca-file", clientCert}, exitError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var code int
+			captureStdout(t, func() { code = runHealthcheck(append(serverArgs, tt.args...)) })
+			assert.Equal(t, tt.want, code)
+		})
+	}
+
+	// A client certificate is optional when the server only verifies the ones given
+	t.Setenv("FBAPI_TLS_CLIENT_AUTH", "verify-if-given")
+	t.Setenv("FBAPI_HEALTHCHECK_CLIENT_CERT_FILE", clientCert)
+	t.Setenv("FBAPI_HEALTHCHECK_CLIENT_KEY_FILE", clientKey)
+	var code int
+	captureStdout(t, func() { code = runHealthcheck(serverArgs) })
+	assert.Equal(t, exitOK, code)
+}
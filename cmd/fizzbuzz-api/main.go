@@ -1,17 +1,207 @@
+// Command fizzbuzz-api runs the FizzBuzz HTTP API.
+//
+// Usage:
+//
+//	fizzbuzz-api [serve] [flags]
+//	fizzbuzz-api config print [flags]
+//	fizzbuzz-api config validate [flags]
+//	fizzbuzz-api healthcheck [flags]
+//	fizzbuzz-api version
+//
+// Settings are layered as defaults < config file < FBAPI_ environment variables < flags.
+// The config file is given by --config or FBAPI_CONFIG_FILE, and each setting has a flag named after
+// its environment variable, e.g. --max-string-length overrides FBAPI_MAX_STRING_LENGTH.
 package main
 
 import (
+	"errors"
+	"fizzbuzz-api/internal/fizzbuzzapi/config"
 	"fizzbuzz-api/internal/fizzbuzzapi/http"
 	"fizzbuzz-api/internal/fizzbuzzapi/logger"
+	"fizzbuzz-api/internal/fizzbuzzapi/version"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	exitOK    = 0
+	exitError = 1 // Runtime failure, e.g. the server could not start or is unhealthy
+	exitUsage = 2 // Invalid command line or configuration
 )
 
 func main() {
-	logger := logger.NewSlogLogger()
+	os.Exit(run(os.Args[1:]))
+}
+
+func run(args []string) int {
+	// Without subcommand, serve for compatibility with existing deployments
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return runServe(args)
+	}
+
+	switch args[0] {
+	case "serve":
+		return runServe(args[1:])
+	case "config":
+		if len(args) < 2 {
+			fmt.Fprintln(os.Stderr, "usage: fizzbuzz-api config print|validate [flags]")
+			return exitUsage
+		}
+		switch args[1] {
+		case "print":
+			return runConfigPrint(args[2:])
+		case "validate":
+			return runConfigValidate(args[2:])
+		}
+		fmt.Fprintf(os.Stderr, "unknown config command %q\n", args[1])
+		return exitUsage
+	case "healthcheck":
+		return runHealthcheck(args[1:])
+	case "version":
+		fmt.Println("fizzbuzz-api", version.String())
+		return exitOK
+	case "help", "-h", "--help":
+		usage()
+		return exitOK
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n", args[0])
+		usage()
+		return exitUsage
+	}
+}
+
+func usage() {
+	fmt.Fprint(os.Stderr, `Usage: fizzbuzz-api <command> [flags]
+
+Commands:
+  serve             run the API server (default)
+  config print      print the resolved config, secrets redacted
+  config validate   check the resolved config
+  healthcheck       query the health endpoint of a running server, exits 0 when healthy
+  version           print build information
+
+Run "fizzbuzz-api <command> -h" for the flags of a command.
+`)
+}
 
-	server, err := http.NewServer(logger)
+func runServe(args []string) int {
+	log := logger.NewSlogLogger()
+	cfg, src, code := loadConfig(flag.NewFlagSet("serve", flag.ContinueOnError), args, log)
+	if cfg == nil {
+		return code
+	}
+	if err := logger.SetLevel(cfg.LogLevel); err != nil {
+		log.Error("invalid log level", "error", err)
+		return exitUsage
+	}
+
+	server, err := http.NewServer(cfg, log)
 	if err != nil {
-		panic(err)
+		log.Error("failed to create server", "error", err)
+		return exitError
+	}
+	server.EnableReload(src.file, func() (*config.Config, error) {
+		return src.load(log)
+	})
+	if err := server.Run(); err != nil {
+		log.Error("fizzbuzz-api stopped with an error", "error", err)
+		return exitError
+	}
+	log.Info("shutting down fizzbuzz-api")
+	return exitOK
+}
+
+func runConfigPrint(args []string) int {
+	cfg, _, code := loadConfig(flag.NewFlagSet("config print", flag.ContinueOnError), args, logger.NewNopLogger())
+	if cfg == nil {
+		return code
+	}
+
+	redacted := cfg.Redacted()
+	for _, f := range config.Fields() {
+		fmt.Printf("%s=%s\n", f.Env(), redacted.Get(f.Name))
+	}
+	return exitOK
+}
+
+func runConfigValidate(args []string) int {
+	cfg, _, code := loadConfig(flag.NewFlagSet("config validate", flag.ContinueOnError), args, logger.NewNopLogger())
+	if cfg == nil {
+		return code
+	}
+	fmt.Println("config is valid")
+	return exitOK
+}
+
+// configSource resolves the config from the command line, so that it can be loaded again on reload
+type configSource struct {
+	file      string
+	overrides func(cfg *config.Config) error
+}
+
+// load loads the config from the file and the environment, applies the flags on top and validates it
+func (src *configSource) load(log logger.Logger) (*config.Config, error) {
+	cfg, err := config.LoadConfig(log, src.file)
+	if err != nil {
+		return nil, err
+	}
+	if err := src.overrides(cfg); err != nil {
+		return nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// loadConfig registers the config flags on fs, parses them and loads the config.
+// On failure it reports the error and returns a nil config with the exit code to use.
+func loadConfig(fs *flag.FlagSet, args []string, log logger.Logger) (*config.Config, *configSource, int) {
+	src := &configSource{}
+	fs.StringVar(&src.file, "config", "", "YAML or TOML config file, overrides "+config.ConfigFileEnv)
+	src.overrides = configFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil, nil, exitOK
+		}
+		return nil, nil, exitUsage
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(os.Stderr, "unexpected arguments: %v\n", fs.Args())
+		return nil, nil, exitUsage
+	}
+	if src.file == "" {
+		src.file = os.Getenv(config.ConfigFileEnv)
+	}
+
+	cfg, err := src.load(log)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "invalid config:", err)
+		return nil, nil, exitUsage
+	}
+	return cfg, src, exitOK
+}
+
+// configFlags registers one flag per config field, named after its environment variable.
+// The returned function applies the flags set on the command line to a loaded config.
+func configFlags(fs *flag.FlagSet) func(cfg *config.Config) error {
+	values := map[string]*string{}
+	flagNames := map[string]string{}
+	for _, f := range config.Fields() {
+		name := strings.ToLower(strings.ReplaceAll(f.Name, "_", "-"))
+		values[name] = fs.String(name, "", "overrides "+f.Env())
+		flagNames[name] = f.Name
+	}
+
+	return func(cfg *config.Config) error {
+		var err error
+		fs.Visit(func(fl *flag.Flag) {
+			if field, ok := flagNames[fl.Name]; ok && err == nil {
+				err = cfg.SetFrom(field, *values[fl.Name], "--"+fl.Name)
+			}
+		})
+		return err
 	}
-	server.Run()
-	logger.Info("shutting down fizzbuzz-api")
 }
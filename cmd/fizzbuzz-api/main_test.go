@@ -0,0 +1,88 @@
+package main
+
+import (
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// captureStdout returns what f writes to the standard output
+func captureStdout(t *testing.T, f func()) string {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	f()
+	w.Close()
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(out)
+}
+
+func Test_Run_ConfigPrint(t *testing.T) {
+	assert := assert.New(t)
+	t.Setenv("FBAPI_ADMIN_API_KEY", "secret")
+	t.Setenv("FBAPI_MAX_STRING_LENGTH", "10")
+
+	var code int
+	out := captureStdout(t, func() { code = run([]string{"config", "print", "--port", "8080"}) })
+	assert.Equal(exitOK, code)
+	assert.Contains(out, "FBAPI_PORT=8080\n")
+	assert.Contains(out, "FBAPI_MAX_STRING_LENGTH=10\n")
+	assert.Contains(out, "FBAPI_ADMIN_API_KEY=REDACTED\n")
+	assert.NotContains(out, "secret")
+}
+
+func Test_Run_ConfigValidate(t *testing.T) {
+	assert := assert.New(t)
+	var code int
+	out := captureStdout(t, func() { code = run([]string{"config", "validate"}) })
+	assert.Equal(exitOK, code)
+	assert.Equal("config is valid\n", out)
+
+	assert.Equal(exitUsage, run([]string{"config", "validate", "--port", "70000"}))
+	assert.Equal(exitUsage, run([]string{"config", "validate", "--max-string-length", "ten"}))
+	assert.Equal(exitUsage, run([]string{"config", "validate", "--unknown-flag", "1"}))
+	assert.Equal(exitUsage, run([]string{"config", "validate", "extra"}))
+	assert.Equal(exitUsage, run([]string{"config", "validate", "--config", "missing.yaml"}))
+}
+
+func Test_Run_ConfigFile(t *testing.T) {
+	assert := assert.New(t)
+	path := t.TempDir() + "/config.yaml"
+	if err := os.WriteFile(path, []byte("port: 9090\nmax_string_length: 20\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("FBAPI_MAX_STRING_LENGTH", "15")
+
+	// Flags take precedence over the environment, which takes precedence over the file
+	var code int
+	out := captureStdout(t, func() { code = run([]string{"config", "print", "--config", path, "--max-string-length", "12"}) })
+	assert.Equal(exitOK, code)
+	assert.Contains(out, "FBAPI_PORT=9090\n")
+	assert.Contains(out, "FBAPI_MAX_STRING_LENGTH=12\n")
+}
+
+func Test_Run_Commands(t *testing.T) {
+	assert := assert.New(t)
+	var code int
+	out := captureStdout(t, func() { code = run([]string{"version"}) })
+	assert.Equal(exitOK, code)
+	assert.Contains(out, "fizzbuzz-api ")
+
+	assert.Equal(exitOK, run([]string{"help"}))
+	assert.Equal(exitUsage, run([]string{"unknown"}))
+	assert.Equal(exitUsage, run([]string{"config"}))
+	assert.Equal(exitUsage, run([]string{"config", "unknown"}))
+	// An invalid config stops serve before the server starts
+	assert.Equal(exitUsage, run([]string{"serve", "--port", "0"}))
+	assert.Equal(exitUsage, run([]string{"--port", "0"}))
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fizzbuzz-api/internal/fizzbuzzapi/config"
+	"fizzbuzz-api/internal/fizzbuzzapi/controllers"
+	"fizzbuzz-api/internal/fizzbuzzapi/i18n"
+	"fizzbuzz-api/internal/fizzbuzzapi/logger"
+	"fizzbuzz-api/internal/fizzbuzzapi/types"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+)
+
+func runGenerate(args []string) error {
+	fs := flag.NewFlagSet("generate", flag.ContinueOnError)
+	var req types.FizzBuzzRequest
+	fs.IntVar(&req.Int1, "int1", 3, "first divisor")
+	fs.IntVar(&req.Int2, "int2", 5, "second divisor")
+	fs.IntVar(&req.Limit, "limit", 100, "generate numbers from 1 to limit")
+	fs.Func("start", "first number of a range, 1 by default (requires --end)", intFlag(&req.Start))
+	fs.Func("end", "last number of a range, replacing --limit", intFlag(&req.End))
+	fs.Func("step", "difference between numbers of a range, 1 or -1 by default", intFlag(&req.Step))
+	fs.StringVar(&req.Str1, "str1", "fizz", "replacement for multiples of int1")
+	fs.StringVar(&req.Str2, "str2", "buzz", "replacement for multiples of int2")
+	fs.StringVar(&req.NumberFormat, "number-format", "", "format of the numbers: decimal, hex, binary, roman, words or a zero-padded width such as 05")
+	lang := fs.String("lang", "", "language of number words and error messages, as an Accept-Language value: en, fr or es")
+	format := fs.String("format", "lines", "output format: lines, json or csv")
+	remote := fs.Bool("remote", false, "generate on the server instead of locally")
+	client := remoteFlags(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if req.IsRange() && !flagSet(fs, "limit") {
+		req.Limit = 0 // The default limit does not apply to ranges
+	}
+
+	write, ok := outputFormats[*format]
+	if !ok {
+		return &cliError{code: exitUsage, err: fmt.Errorf("unknown format %q, expected lines, json or csv", *format)}
+	}
+
+	req.Locale = string(i18n.Negotiate(*lang))
+	client.acceptLanguage = *lang
+
+	var resp types.FizzBuzzResponse
+	var err error
+	if *remote {
+		resp, err = client.generate(req)
+	} else {
+		resp, err = generateLocally(req)
+	}
+	if err != nil {
+		return err
+	}
+	return write(os.Stdout, resp)
+}
+
+// generateLocally runs the same controller as the server, with limits loaded from the server config (FBAPI_CONFIG_FILE and FBAPI_ variables)
+func generateLocally(req types.FizzBuzzRequest) (types.FizzBuzzResponse, error) {
+	cfg, err := config.LoadConfig(logger.NewNopLogger(), "")
+	if err == nil {
+		err = cfg.Validate()
+	}
+	if err != nil {
+		return types.FizzBuzzResponse{}, &cliError{code: exitUsage, err: fmt.Errorf("invalid configuration: %w", err)}
+	}
+
+	ctrl := controllers.NewFizzBuzzController(types.FizzBuzzLimits{
+		MaxLimit:          cfg.MaxFizzBuzzLimit,
+		MaxStringLength:   cfg.MaxStringLength,
+		MaxTemplateLength: cfg.MaxTemplateLength,
+		MaxExpandedLength: cfg.MaxExpandedLength,
+		StringLengthUnit:  cfg.StringLengthUnit,
+	}, logger.NewNopLogger())
+	resp, err := ctrl.GenerateFizzBuzz(req)
+	if err != nil {
+		return resp, &cliError{code: exitCodeForError(err), err: errors.New(i18n.TranslateError(i18n.Locale(req.Locale), err))}
+	}
+	return resp, nil
+}
+
+// exitCodeForError classifies errors like the server does, so that a request exits with the same code
+// whether it runs locally or remotely
+func exitCodeForError(err error) int {
+	switch controllers.ErrorCode(err) {
+	case controllers.ErrCodeInvalidParameter:
+		return exitInvalidRequest
+	case controllers.ErrCodeLimitExceeded, controllers.ErrCodeStringLengthExceeded:
+		return exitLimitExceeded
+	default:
+		return exitError
+	}
+}
+
+// intFlag sets *p to the flag value, leaving it nil when the flag is not given
+func intFlag(p **int) func(string) error {
+	return func(s string) error {
+		i, err := strconv.Atoi(s)
+		if err != nil {
+			return err
+		}
+		*p = &i
+		return nil
+	}
+}
+
+func flagSet(fs *flag.FlagSet, name string) bool {
+	set := false
+	fs.Visit(func(f *flag.Flag) { set = set || f.Name == name })
+	return set
+}
+
+var outputFormats = map[string]func(w io.Writer, resp types.FizzBuzzResponse) error{
+	"lines": writeLines,
+	"json":  writeJSON,
+	"csv":   writeCSV,
+}
+
+func writeLines(w io.Writer, resp types.FizzBuzzResponse) error {
+	for _, v := range resp.Result {
+		if _, err := fmt.Fprintln(w, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeJSON(w io.Writer, resp types.FizzBuzzResponse) error {
+	return json.NewEncoder(w).Encode(resp)
+}
+
+func writeCSV(w io.Writer, resp types.FizzBuzzResponse) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"index", "value"})
+	for i, v := range resp.Result {
+		cw.Write([]string{strconv.Itoa(i + 1), v})
+	}
+	cw.Flush()
+	return cw.Error()
+}
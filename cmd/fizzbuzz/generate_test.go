@@ -0,0 +1,152 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fizzbuzz-api/internal/fizzbuzzapi/config"
+	"fizzbuzz-api/internal/fizzbuzzapi/controllers"
+	"fizzbuzz-api/internal/fizzbuzzapi/handlers"
+	"fizzbuzz-api/internal/fizzbuzzapi/logger"
+	"fizzbuzz-api/internal/fizzbuzzapi/types"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ExitCodeForError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{controllers.ErrLimitExceeded, exitLimitExceeded},
+		{controllers.ErrStringLengthExceeded, exitLimitExceeded},
+		{controllers.ErrTemplateLengthExceeded, exitLimitExceeded},
+		{controllers.ErrExpansionExceeded, exitLimitExceeded},
+		{controllers.ErrNegativeParameter, exitInvalidRequest},
+		{controllers.ErrInvalidRange, exitInvalidRequest},
+		{controllers.ErrInvalidNumberFormat, exitInvalidRequest},
+		{controllers.ErrInvalidTemplate, exitInvalidRequest},
+		{controllers.ErrUnsafeCharacter, exitInvalidRequest},
+		{controllers.ErrTooManyDigits, exitInvalidRequest},
+		{fmt.Errorf("limit 200: %w", controllers.ErrLimitExceeded), exitLimitExceeded},
+		{errors.New("unexpected"), exitError},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, exitCodeForError(tt.err), tt.err.Error())
+	}
+}
+
+// failingGenerator fails every request with err, standing for the controller behind the server
+type failingGenerator struct{ err error }
+
+func (g failingGenerator) GenerateFizzBuzz(types.FizzBuzzRequest) (types.FizzBuzzResponse, error) {
+	return types.FizzBuzzResponse{}, g.err
+}
+
+type nopRecorder struct{}
+
+func (nopRecorder) GetStats() types.FizzBuzzStats            { return types.FizzBuzzStats{} }
+func (nopRecorder) SaveStat(req types.FizzBuzzRequest) error { return nil }
+
+// controllerErrors lists the exported errors of the controllers package
+var controllerErrors = map[string]error{
+	"ErrLimitExceeded": controllers.ErrLimitExceeded, "ErrStringLengthExceeded": controllers.ErrStringLengthExceeded,
+	"ErrNegativeParameter": controllers.ErrNegativeParameter, "ErrInvalidRange": controllers.ErrInvalidRange,
+	"ErrUnsafeCharacter": controllers.ErrUnsafeCharacter, "ErrEmptySequence": controllers.ErrEmptySequence,
+	"ErrTooManyDigits": controllers.ErrTooManyDigits, "ErrInvalidNumberFormat": controllers.ErrInvalidNumberFormat,
+	"ErrInvalidTemplate": controllers.ErrInvalidTemplate, "ErrTemplateLengthExceeded": controllers.ErrTemplateLengthExceeded,
+	"ErrExpansionExceeded": controllers.ErrExpansionExceeded, "ErrCompactUnsupported": controllers.ErrCompactUnsupported,
+	"ErrPositionalTemplate": controllers.ErrPositionalTemplate,
+}
+
+func Test_ExitCodes_LocalAndRemote(t *testing.T) {
+	// Every exported error declared by the controllers must be listed
+	names, err := filepath.Glob("../../internal/fizzbuzzapi/controllers/*.go")
+	assert.NoError(t, err)
+	for _, name := range names {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(token.NewFileSet(), name, nil, 0)
+		assert.NoError(t, err)
+		for _, decl := range file.Decls {
+			if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.VAR {
+				for _, spec := range gen.Specs {
+					for _, ident := range spec.(*ast.ValueSpec).Names {
+						if strings.HasPrefix(ident.Name, "Err") {
+							assert.Contains(t, controllerErrors, ident.Name, "%s is not checked for exit codes", ident.Name)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	gin.SetMode(gin.TestMode)
+	for name, sentinel := range controllerErrors {
+		t.Run(name, func(t *testing.T) {
+			err := fmt.Errorf("%w: details", sentinel)
+			router := gin.New()
+			h := handlers.NewFizzBuzzHandler(&config.Config{}, logger.NewNopLogger(), failingGenerator{err}, nopRecorder{})
+			router.POST("/fizzbuzz/generate", h.GenerateFizzBuzz)
+			server := httptest.NewServer(router)
+			defer server.Close()
+
+			client := &remoteClient{baseURL: server.URL}
+			_, remoteErr := client.generate(types.FizzBuzzRequest{Int1: 3, Int2: 5, Limit: 3, Str1: "fizz", Str2: "buzz"})
+			var cliErr *cliError
+			assert.ErrorAs(t, remoteErr, &cliErr)
+			assert.Equal(t, cliErr.code, exitCodeForError(err))
+		})
+	}
+}
+
+func Test_GenerateLocally(t *testing.T) {
+	assert := assert.New(t)
+	t.Setenv("FBAPI_MAX_FIZZBUZZ_LIMIT", "20")
+
+	resp, err := generateLocally(types.FizzBuzzRequest{Int1: 3, Int2: 5, Limit: 5, Str1: "fizz", Str2: "buzz"})
+	assert.NoError(err)
+	assert.Equal([]string{"1", "2", "fizz", "4", "buzz"}, resp.Result)
+
+	_, err = generateLocally(types.FizzBuzzRequest{Int1: 3, Int2: 5, Limit: 21, Str1: "fizz", Str2: "buzz"})
+	var cliErr *cliError
+	assert.ErrorAs(err, &cliErr)
+	assert.Equal(exitLimitExceeded, cliErr.code)
+}
+
+func Test_OutputFormats(t *testing.T) {
+	resp := types.FizzBuzzResponse{Result: []string{"1", "fizz", "a,b"}}
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"lines", "1\nfizz\na,b\n"},
+		{"json", `{"result":["1","fizz","a,b"],"duration_ms":0}` + "\n"},
+		{"csv", "index,value\n1,1\n2,fizz\n3,\"a,b\"\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var buf bytes.Buffer
+			assert.NoError(t, outputFormats[tt.format](&buf, resp))
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func Test_Run_Usage(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal(exitUsage, run(nil))
+	assert.Equal(exitUsage, run([]string{"unknown"}))
+	assert.Equal(exitUsage, run([]string{"generate", "--format", "xml"}))
+	assert.Equal(exitUsage, run([]string{"generate", "extra"}))
+	assert.Equal(exitOK, run([]string{"help"}))
+}
@@ -0,0 +1,116 @@
+// Command fizzbuzz generates FizzBuzz sequences locally or talks to a remote fizzbuzz-api server.
+//
+// Usage:
+//
+//	fizzbuzz generate [--remote] [--format lines|json|csv] --int1 3 --int2 5 --limit 15 --str1 fizz --str2 buzz
+//	fizzbuzz stats    [--base-url URL] [--api-key KEY]
+//	fizzbuzz health   [--base-url URL] [--api-key KEY]
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+)
+
+// Exit codes, mapped from the API error classes so that shell pipelines can branch on them
+const (
+	exitOK               = 0
+	exitError            = 1 // Unexpected error
+	exitUsage            = 2 // Invalid command line
+	exitInvalidRequest   = 3 // Invalid parameters (400)
+	exitLimitExceeded    = 4 // Limits exceeded (422)
+	exitUnavailable      = 5 // Server unreachable or failing (5xx)
+	exitPermissionDenied = 6 // Missing or invalid API key (401, 403)
+)
+
+// cliError carries the exit code matching the failure
+type cliError struct {
+	code int
+	err  error
+}
+
+func (e *cliError) Error() string { return e.err.Error() }
+func (e *cliError) Unwrap() error { return e.err }
+
+func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+func run(args []string) int {
+	if len(args) == 0 {
+		usage()
+		return exitUsage
+	}
+
+	var err error
+	switch args[0] {
+	case "generate":
+		err = runGenerate(args[1:])
+	case "stats":
+		err = runStats(args[1:])
+	case "health":
+		err = runHealth(args[1:])
+	case "help", "-h", "--help":
+		usage()
+		return exitOK
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n", args[0])
+		usage()
+		return exitUsage
+	}
+
+	if err == nil {
+		return exitOK
+	}
+	if errors.Is(err, flag.ErrHelp) {
+		return exitOK
+	}
+	fmt.Fprintln(os.Stderr, "error:", err)
+	var cliErr *cliError
+	if errors.As(err, &cliErr) {
+		return cliErr.code
+	}
+	return exitError
+}
+
+func usage() {
+	fmt.Fprint(os.Stderr, `Usage: fizzbuzz <command> [flags]
+
+Commands:
+  generate   generate a FizzBuzz sequence, locally or with --remote on a server
+  stats      print the stats of a server
+  health     check the health of a server
+
+Run "fizzbuzz <command> -h" for the flags of a command.
+`)
+}
+
+// remoteFlags registers the flags shared by the commands calling a server
+func remoteFlags(fs *flag.FlagSet) *remoteClient {
+	client := &remoteClient{}
+	fs.StringVar(&client.baseURL, "base-url", envOr("FIZZBUZZ_BASE_URL", "http://localhost:4255"), "base URL of the fizzbuzz-api server (env FIZZBUZZ_BASE_URL)")
+	fs.StringVar(&client.apiKey, "api-key", os.Getenv("FIZZBUZZ_API_KEY"), "API key sent in the X-API-Key header (env FIZZBUZZ_API_KEY)")
+	return client
+}
+
+func envOr(key, fallback string) string {
+	if v, ok := os.LookupEnv(key); ok {
+		return v
+	}
+	return fallback
+}
+
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return &cliError{code: exitUsage, err: err}
+	}
+	if fs.NArg() > 0 {
+		return &cliError{code: exitUsage, err: fmt.Errorf("unexpected arguments: %v", fs.Args())}
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fizzbuzz-api/internal/fizzbuzzapi/middleware"
+	"fizzbuzz-api/internal/fizzbuzzapi/types"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+type remoteClient struct {
+	baseURL        string
+	apiKey         string
+	acceptLanguage string
+}
+
+func runStats(args []string) error {
+	fs := flag.NewFlagSet("stats", flag.ContinueOnError)
+	client := remoteFlags(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	body, err := client.do(http.MethodGet, "/fizzbuzz/stats", nil)
+	if err != nil {
+		return err
+	}
+	return printJSON(body)
+}
+
+func runHealth(args []string) error {
+	fs := flag.NewFlagSet("health", flag.ContinueOnError)
+	client := remoteFlags(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	body, err := client.do(http.MethodGet, "/fizzbuzz/health", nil)
+	if err != nil {
+		return err
+	}
+	return printJSON(body)
+}
+
+func (c *remoteClient) generate(req types.FizzBuzzRequest) (types.FizzBuzzResponse, error) {
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return types.FizzBuzzResponse{}, err
+	}
+	body, err := c.do(http.MethodPost, "/fizzbuzz/generate", payload)
+	if err != nil {
+		return types.FizzBuzzResponse{}, err
+	}
+
+	var resp types.FizzBuzzResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return resp, &cliError{code: exitUnavailable, err: fmt.Errorf("invalid response from server: %w", err)}
+	}
+	return resp, nil
+}
+
+// do sends a request to the server and returns the response body, mapping HTTP failures to exit codes
+func (c *remoteClient) do(method, path string, payload []byte) ([]byte, error) {
+	req, err := http.NewRequest(method, strings.TrimSuffix(c.baseURL, "/")+path, bytes.NewReader(payload))
+	if err != nil {
+		return nil, &cliError{code: exitUsage, err: err}
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.apiKey != "" {
+		req.Header.Set(middleware.APIKeyHeader, c.apiKey)
+	}
+	if c.acceptLanguage != "" {
+		req.Header.Set("Accept-Language", c.acceptLanguage)
+	}
+
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, &cliError{code: exitUnavailable, err: err}
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, &cliError{code: exitUnavailable, err: err}
+	}
+	if resp.StatusCode >= 300 {
+		return nil, &cliError{code: exitCodeForStatus(resp.StatusCode), err: fmt.Errorf("%s: %s", resp.Status, apiErrorMessage(body))}
+	}
+	return body, nil
+}
+
+func exitCodeForStatus(status int) int {
+	switch {
+	case status == http.StatusUnauthorized, status == http.StatusForbidden:
+		return exitPermissionDenied
+	case status == http.StatusUnprocessableEntity:
+		return exitLimitExceeded
+	case status >= 500:
+		return exitUnavailable
+	case status >= 400:
+		return exitInvalidRequest
+	default:
+		return exitError
+	}
+}
+
+// apiErrorMessage extracts the message of an {"error": "..."} response, falling back to the raw body
+func apiErrorMessage(body []byte) string {
+	var apiErr struct {
+		Error string `json:"error"`
+	}
+	if json.Unmarshal(body, &apiErr) == nil && apiErr.Error != "" {
+		return apiErr.Error
+	}
+	return strings.TrimSpace(string(body))
+}
+
+func printJSON(body []byte) error {
+	var out bytes.Buffer
+	if err := json.Indent(&out, body, "", "  "); err != nil {
+		return &cliError{code: exitUnavailable, err: fmt.Errorf("invalid response from server: %w", err)}
+	}
+	out.WriteByte('\n')
+	_, err := out.WriteTo(os.Stdout)
+	return err
+}
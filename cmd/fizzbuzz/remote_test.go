@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fizzbuzz-api/internal/fizzbuzzapi/middleware"
+	"fizzbuzz-api/internal/fizzbuzzapi/types"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ExitCodeForStatus(t *testing.T) {
+	tests := []struct {
+		status int
+		want   int
+	}{
+		{http.StatusBadRequest, exitInvalidRequest},
+		{http.StatusNotFound, exitInvalidRequest},
+		{http.StatusUnauthorized, exitPermissionDenied},
+		{http.StatusForbidden, exitPermissionDenied},
+		{http.StatusUnprocessableEntity, exitLimitExceeded},
+		{http.StatusInternalServerError, exitUnavailable},
+		{http.StatusServiceUnavailable, exitUnavailable},
+		{http.StatusMultipleChoices, exitError},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, exitCodeForStatus(tt.status), http.StatusText(tt.status))
+	}
+}
+
+func Test_ApiErrorMessage(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal("limit exceeded", apiErrorMessage([]byte(`{"error":"limit exceeded","code":"limit_exceeded"}`)))
+	assert.Equal("Bad Gateway", apiErrorMessage([]byte("Bad Gateway\n")))
+}
+
+func Test_RemoteClient_Generate(t *testing.T) {
+	assert := assert.New(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("/fizzbuzz/generate", r.URL.Path)
+		assert.Equal("application/json", r.Header.Get("Content-Type"))
+		if r.Header.Get(middleware.APIKeyHeader) != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":"invalid API key"}`))
+			return
+		}
+		w.Write([]byte(`{"result":["1","2","fizz"]}`))
+	}))
+	defer server.Close()
+
+	client := &remoteClient{baseURL: server.URL + "/", apiKey: "secret"}
+	resp, err := client.generate(types.FizzBuzzRequest{Int1: 3, Int2: 5, Limit: 3, Str1: "fizz", Str2: "buzz"})
+	assert.NoError(err)
+	assert.Equal([]string{"1", "2", "fizz"}, resp.Result)
+
+	client.apiKey = "wrong"
+	_, err = client.generate(types.FizzBuzzRequest{Int1: 3, Int2: 5, Limit: 3, Str1: "fizz", Str2: "buzz"})
+	var cliErr *cliError
+	assert.ErrorAs(err, &cliErr)
+	assert.Equal(exitPermissionDenied, cliErr.code)
+	assert.ErrorContains(err, "invalid API key")
+}
+
+func Test_RemoteClient_Unreachable(t *testing.T) {
+	assert := assert.New(t)
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	client := &remoteClient{baseURL: server.URL}
+	_, err := client.do(http.MethodGet, "/fizzbuzz/health", nil)
+	var cliErr *cliError
+	assert.ErrorAs(err, &cliErr)
+	assert.Equal(exitUnavailable, cliErr.code)
+}
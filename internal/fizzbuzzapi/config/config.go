@@ -1,7 +1,15 @@
 package config
 
 import (
+	"errors"
 	"fizzbuzz-api/internal/fizzbuzzapi/logger"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/kelseyhightower/envconfig"
 )
@@ -10,21 +18,270 @@ type Config struct {
 	Port string `envconfig:"PORT" default:"4255"`
 	Host string `envconfig:"HOST" default:"localhost"`
 
+	GRPCPort string `envconfig:"GRPC_PORT"` // Port of the gRPC API, served alongside the REST API; empty (the default) disables it
+
 	MaxFizzBuzzLimit int    `envconfig:"MAX_FIZZBUZZ_LIMIT" default:"100000"` // Max limit for FizzBuzz generation
 	MaxStringLength  int    `envconfig:"MAX_STRING_LENGTH" default:"30"`      // Max length for Str1 and Str2
-	StatsStorage     string `envconfig:"STATS_STORAGE" default:"inmemory"`    // Storage type for stats: "inmemory" or "file"
+	StatsStorage     string `envconfig:"STATS_STORAGE" default:"inmemory"`    // Storage type for stats, only "inmemory" is implemented and other values fall back to it
+
+	MaxTemplateLength int    `envconfig:"MAX_TEMPLATE_LENGTH" default:"64"`   // Max length for Str1 and Str2 holding {n} placeholders
+	MaxExpandedLength int    `envconfig:"MAX_EXPANDED_LENGTH" default:"256"`  // Max length of a value generated from templates or a number format
+	StringLengthUnit  string `envconfig:"STRING_LENGTH_UNIT" default:"bytes"` // How string lengths are counted: "bytes", "runes" or "graphemes"
+
+	StatsKeying        string `envconfig:"STATS_KEYING" default:"exact"`     // Which requests are counted together: "exact", "symmetric" or "output-equivalent"
+	StatsRetentionDays int    `envconfig:"STATS_RETENTION_DAYS" default:"0"` // Drop stats entries not seen within N days, 0 keeps them forever
+	AdminAPIKey        string `envconfig:"ADMIN_API_KEY" secret:"true"`      // API key for admin routes, admin routes are disabled when empty
+
+	LogLevel             string        `envconfig:"LOG_LEVEL" default:"info"`            // "debug", "info", "warn" or "error"
+	ConfigReloadInterval time.Duration `envconfig:"CONFIG_RELOAD_INTERVAL" default:"5s"` // How often the config file is checked for changes, 0 disables the check
+
+	// HTTP server timeouts, 0 means no timeout
+	ReadHeaderTimeout time.Duration `envconfig:"READ_HEADER_TIMEOUT" default:"5s"`
+	ReadTimeout       time.Duration `envconfig:"READ_TIMEOUT" default:"30s"`
+	WriteTimeout      time.Duration `envconfig:"WRITE_TIMEOUT" default:"60s"` // Large sequences take time to write
+	IdleTimeout       time.Duration `envconfig:"IDLE_TIMEOUT" default:"120s"`
+
+	Compression             string `envconfig:"COMPRESSION" default:"zstd,gzip"`              // Response codings by preference, among "zstd" and "gzip"; empty disables compression
+	CompressionMinSize      int    `envconfig:"COMPRESSION_MIN_SIZE" default:"1024"`          // Responses smaller than this many bytes are not compressed, unless streamed
+	MaxDecompressedBodySize int    `envconfig:"MAX_DECOMPRESSED_BODY_SIZE" default:"1048576"` // Max size of compressed request bodies once decoded
+	MaxSubmissionSize       int    `envconfig:"MAX_SUBMISSION_SIZE" default:"16777216"`       // Max size of the sequences submitted to verify and infer, once decoded
+
+	ShutdownDrainDelay time.Duration `envconfig:"SHUTDOWN_DRAIN_DELAY" default:"0s"` // Time between failing readiness and closing connections, for load balancers to notice
+	ShutdownTimeout    time.Duration `envconfig:"SHUTDOWN_TIMEOUT" default:"30s"`    // Maximum time given to in-flight requests, then to shutdown hooks
+
+	// TLS is enabled when both the certificate and the key are set, files are reloaded when they change
+	TLSCertFile     string `envconfig:"TLS_CERT_FILE"`
+	TLSKeyFile      string `envconfig:"TLS_KEY_FILE"`
+	TLSMinVersion   string `envconfig:"TLS_MIN_VERSION" default:"1.2"`       // "1.2" or "1.3"
+	TLSCipherPolicy string `envconfig:"TLS_CIPHER_POLICY" default:"default"` // "default" (Go defaults) or "strict" (ECDHE with AEAD ciphers only)
+	TLSClientCAFile string `envconfig:"TLS_CLIENT_CA_FILE"`                  // CA bundle verifying client certificates, enables mutual TLS
+	TLSClientAuth   string `envconfig:"TLS_CLIENT_AUTH" default:"require"`   // With a client CA: "require" or "verify-if-given"
+
+	// Where each setting not left to its default comes from, e.g. "config.yaml:3", "FBAPI_PORT" or "--port"
+	sources map[string]string
 }
 
-func LoadConfig(log logger.Logger) (*Config, error) {
-	cfg := Config{}
+// Environment variable pointing to the optional config file
+const ConfigFileEnv = EnvPrefix + "CONFIG_FILE"
 
-	// Load environment variables into the config struct
+// LoadConfig loads the config with the following precedence: defaults < config file < environment variables.
+// The config file is read from path, or from FBAPI_CONFIG_FILE when path is empty; it is optional.
+func LoadConfig(log logger.Logger, path string) (*Config, error) {
+	cfg := Config{sources: map[string]string{}}
+
+	// Load defaults and environment variables into the config struct
 	// Environment variables must be prefixed with "FBAPI_"
 	err := envconfig.Process("FBAPI", &cfg)
 	if err != nil {
 		return nil, err
 	}
+	for _, f := range Fields() {
+		if _, ok := os.LookupEnv(f.Env()); ok {
+			cfg.sources[f.Name] = f.Env()
+		}
+	}
+
+	if path == "" {
+		path = os.Getenv(ConfigFileEnv)
+	}
+	if path != "" {
+		if err := cfg.applyFile(path); err != nil {
+			return nil, err
+		}
+	}
 
-	log.Info("config loaded", "config", cfg)
+	log.Info("config loaded", "config", cfg, "file", path)
 	return &cfg, nil
 }
+
+// applyFile sets the settings of a config file, unless their environment variable takes precedence
+func (cfg *Config) applyFile(path string) error {
+	settings, err := readFile(path)
+	if err != nil {
+		return err
+	}
+
+	fields := map[string]Field{}
+	for _, f := range Fields() {
+		fields[f.FileKey()] = f
+	}
+
+	var errs []error
+	for _, s := range settings {
+		source := fmt.Sprintf("%s:%d", path, s.line)
+		f, ok := fields[s.key]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: unknown key %q", source, s.key))
+			continue
+		}
+		if _, ok := os.LookupEnv(f.Env()); ok {
+			continue
+		}
+		if err := cfg.SetFrom(f.Name, s.value, source); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// SetFrom sets a setting like Set, and records where the value comes from for error reporting
+func (cfg *Config) SetFrom(name, value, source string) error {
+	if err := cfg.Set(name, value); err != nil {
+		return fmt.Errorf("%s: %w", source, err)
+	}
+	if cfg.sources == nil {
+		cfg.sources = map[string]string{}
+	}
+	cfg.sources[name] = source
+	return nil
+}
+
+// Redacted returns a copy of the config with secrets masked, safe for logging
+func (cfg Config) Redacted() Config {
+	for _, f := range Fields() {
+		if f.Secret && cfg.Get(f.Name) != "" {
+			cfg.Set(f.Name, "REDACTED")
+		}
+	}
+	return cfg
+}
+
+// LogValue logs the settings by environment variable, secrets redacted
+func (cfg Config) LogValue() slog.Value {
+	redacted := cfg.Redacted()
+	attrs := make([]slog.Attr, 0, len(Fields()))
+	for _, f := range Fields() {
+		attrs = append(attrs, slog.String(f.Env(), redacted.Get(f.Name)))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// Settings that can change on a config reload, the others require a restart
+var ReloadableFields = []string{"MAX_FIZZBUZZ_LIMIT", "MAX_STRING_LENGTH", "MAX_TEMPLATE_LENGTH", "MAX_EXPANDED_LENGTH", "STRING_LENGTH_UNIT", "LOG_LEVEL"}
+
+var (
+	validTLSVersions       = []string{"1.2", "1.3"}
+	validTLSCipherPolicies = []string{"default", "strict"}
+	validTLSClientAuths    = []string{"require", "verify-if-given"}
+	validLogLevels         = []string{"debug", "info", "warn", "error"}
+	validStatsKeyings      = []string{"exact", "symmetric", "output-equivalent"}
+	validCompressions      = []string{"zstd", "gzip"}
+	validStringLengthUnits = []string{"bytes", "runes", "graphemes"}
+)
+
+// CompressionEncodings lists the response codings of COMPRESSION, by preference
+func (cfg *Config) CompressionEncodings() []string {
+	var encodings []string
+	for _, encoding := range strings.Split(cfg.Compression, ",") {
+		if encoding = strings.TrimSpace(encoding); encoding != "" {
+			encodings = append(encodings, encoding)
+		}
+	}
+	return encodings
+}
+
+func (cfg *Config) TLSEnabled() bool {
+	return cfg.TLSCertFile != "" && cfg.TLSKeyFile != ""
+}
+
+// Validate checks the values of the config, reporting every invalid field along with where its value comes from
+func (cfg *Config) Validate() error {
+	var errs []error
+	invalid := func(name, format string, args ...any) {
+		err := fmt.Errorf("%s: %s", name, fmt.Sprintf(format, args...))
+		if source, ok := cfg.sources[name]; ok {
+			err = fmt.Errorf("%s: %w", source, err)
+		}
+		errs = append(errs, err)
+	}
+
+	validPort := func(name, value string) {
+		if port, err := strconv.Atoi(value); err != nil || port < 1 || port > 65535 {
+			invalid(name, "%q is not a valid port (1-65535)", value)
+		}
+	}
+	validPort("PORT", cfg.Port)
+	if cfg.GRPCPort != "" {
+		validPort("GRPC_PORT", cfg.GRPCPort)
+		if cfg.GRPCPort == cfg.Port {
+			invalid("GRPC_PORT", "must differ from PORT (%s)", cfg.Port)
+		}
+	}
+	if cfg.MaxFizzBuzzLimit <= 0 {
+		invalid("MAX_FIZZBUZZ_LIMIT", "must be strictly positive, got %d", cfg.MaxFizzBuzzLimit)
+	}
+	if cfg.MaxStringLength <= 0 {
+		invalid("MAX_STRING_LENGTH", "must be strictly positive, got %d", cfg.MaxStringLength)
+	}
+	if cfg.MaxTemplateLength <= 0 {
+		invalid("MAX_TEMPLATE_LENGTH", "must be strictly positive, got %d", cfg.MaxTemplateLength)
+	}
+	if cfg.MaxExpandedLength <= 0 {
+		invalid("MAX_EXPANDED_LENGTH", "must be strictly positive, got %d", cfg.MaxExpandedLength)
+	}
+	if !slices.Contains(validStringLengthUnits, cfg.StringLengthUnit) {
+		invalid("STRING_LENGTH_UNIT", "unknown unit %q, expected one of %v", cfg.StringLengthUnit, validStringLengthUnits)
+	}
+	if !slices.Contains(validStatsKeyings, cfg.StatsKeying) {
+		invalid("STATS_KEYING", "unknown keying %q, expected one of %v", cfg.StatsKeying, validStatsKeyings)
+	}
+	if cfg.StatsRetentionDays < 0 {
+		invalid("STATS_RETENTION_DAYS", "must be positive, got %d", cfg.StatsRetentionDays)
+	}
+	if !slices.Contains(validLogLevels, strings.ToLower(cfg.LogLevel)) {
+		invalid("LOG_LEVEL", "unknown level %q, expected one of %v", cfg.LogLevel, validLogLevels)
+	}
+	if cfg.ConfigReloadInterval < 0 {
+		invalid("CONFIG_RELOAD_INTERVAL", "must be positive, got %s", cfg.ConfigReloadInterval)
+	}
+	for _, timeout := range []struct {
+		name string
+		d    time.Duration
+	}{
+		{"READ_HEADER_TIMEOUT", cfg.ReadHeaderTimeout},
+		{"READ_TIMEOUT", cfg.ReadTimeout},
+		{"WRITE_TIMEOUT", cfg.WriteTimeout},
+		{"IDLE_TIMEOUT", cfg.IdleTimeout},
+		{"SHUTDOWN_DRAIN_DELAY", cfg.ShutdownDrainDelay},
+	} {
+		if timeout.d < 0 {
+			invalid(timeout.name, "must be positive, got %s", timeout.d)
+		}
+	}
+	for _, encoding := range cfg.CompressionEncodings() {
+		if !slices.Contains(validCompressions, encoding) {
+			invalid("COMPRESSION", "unknown encoding %q, expected one of %v", encoding, validCompressions)
+		}
+	}
+	if cfg.CompressionMinSize < 0 {
+		invalid("COMPRESSION_MIN_SIZE", "must be positive, got %d", cfg.CompressionMinSize)
+	}
+	if cfg.MaxDecompressedBodySize <= 0 {
+		invalid("MAX_DECOMPRESSED_BODY_SIZE", "must be strictly positive, got %d", cfg.MaxDecompressedBodySize)
+	}
+	if cfg.MaxSubmissionSize <= 0 {
+		invalid("MAX_SUBMISSION_SIZE", "must be strictly positive, got %d", cfg.MaxSubmissionSize)
+	}
+	if cfg.ShutdownTimeout <= 0 {
+		invalid("SHUTDOWN_TIMEOUT", "must be strictly positive, got %s", cfg.ShutdownTimeout)
+	}
+	if cfg.ReadTimeout > 0 && cfg.ReadHeaderTimeout > cfg.ReadTimeout {
+		invalid("READ_HEADER_TIMEOUT", "must not exceed READ_TIMEOUT (%s), got %s", cfg.ReadTimeout, cfg.ReadHeaderTimeout)
+	}
+	if (cfg.TLSCertFile == "") != (cfg.TLSKeyFile == "") {
+		invalid("TLS_CERT_FILE", "TLS_CERT_FILE and TLS_KEY_FILE must be set together")
+	}
+	if cfg.TLSClientCAFile != "" && !cfg.TLSEnabled() {
+		invalid("TLS_CLIENT_CA_FILE", "requires TLS_CERT_FILE and TLS_KEY_FILE")
+	}
+	if !slices.Contains(validTLSVersions, cfg.TLSMinVersion) {
+		invalid("TLS_MIN_VERSION", "unknown version %q, expected one of %v", cfg.TLSMinVersion, validTLSVersions)
+	}
+	if !slices.Contains(validTLSCipherPolicies, cfg.TLSCipherPolicy) {
+		invalid("TLS_CIPHER_POLICY", "unknown policy %q, expected one of %v", cfg.TLSCipherPolicy, validTLSCipherPolicies)
+	}
+	if !slices.Contains(validTLSClientAuths, cfg.TLSClientAuth) {
+		invalid("TLS_CLIENT_AUTH", "unknown mode %q, expected one of %v", cfg.TLSClientAuth, validTLSClientAuths)
+	}
+	return errors.Join(errs...)
+}
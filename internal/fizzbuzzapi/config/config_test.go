@@ -0,0 +1,123 @@
+package config
+
+import (
+	"fizzbuzz-api/internal/fizzbuzzapi/logger"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeConfigFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func Test_LoadConfig_Defaults(t *testing.T) {
+	assert := assert.New(t)
+	cfg, err := LoadConfig(logger.NewNopLogger(), "")
+	assert.NoError(err)
+	assert.Equal("4255", cfg.Port)
+	assert.Equal(100000, cfg.MaxFizzBuzzLimit)
+	assert.Equal("bytes", cfg.StringLengthUnit, "Lengths are counted in bytes unless operators opt in to another unit")
+	assert.NoError(cfg.Validate())
+
+	cfg.StringLengthUnit = "characters"
+	assert.ErrorContains(cfg.Validate(), `STRING_LENGTH_UNIT: unknown unit "characters"`)
+
+	cfg.StringLengthUnit = "bytes"
+	cfg.MaxFizzBuzzLimit = 0
+	assert.ErrorContains(cfg.Validate(), "MAX_FIZZBUZZ_LIMIT: must be strictly positive, got 0", "A zero limit would reject every request")
+}
+
+func Test_LoadConfig_YAML(t *testing.T) {
+	assert := assert.New(t)
+	path := writeConfigFile(t, "config.yaml", "port: 8080\nmax_string_length: 12\nstats_keying: symmetric\n")
+
+	cfg, err := LoadConfig(logger.NewNopLogger(), path)
+	assert.NoError(err)
+	assert.Equal("8080", cfg.Port)
+	assert.Equal(12, cfg.MaxStringLength)
+	assert.Equal("symmetric", cfg.StatsKeying)
+	assert.Equal("localhost", cfg.Host)
+}
+
+func Test_LoadConfig_TOML(t *testing.T) {
+	assert := assert.New(t)
+	path := writeConfigFile(t, "config.toml", "port = \"8080\"\nmax_fizzbuzz_limit = 50\n")
+
+	cfg, err := LoadConfig(logger.NewNopLogger(), path)
+	assert.NoError(err)
+	assert.Equal("8080", cfg.Port)
+	assert.Equal(50, cfg.MaxFizzBuzzLimit)
+}
+
+func Test_LoadConfig_Precedence(t *testing.T) {
+	assert := assert.New(t)
+	path := writeConfigFile(t, "config.yaml", "port: 8080\nhost: 0.0.0.0\n")
+	t.Setenv("FBAPI_PORT", "9090")
+
+	cfg, err := LoadConfig(logger.NewNopLogger(), path)
+	assert.NoError(err)
+	assert.Equal("9090", cfg.Port, "environment variables override the config file")
+	assert.Equal("0.0.0.0", cfg.Host, "the config file overrides defaults")
+
+	assert.NoError(cfg.SetFrom("PORT", "7070", "--port"))
+	assert.Equal("7070", cfg.Port)
+}
+
+func Test_LoadConfig_FileFromEnv(t *testing.T) {
+	assert := assert.New(t)
+	path := writeConfigFile(t, "config.yml", "max_string_length: 5\n")
+	t.Setenv(ConfigFileEnv, path)
+
+	cfg, err := LoadConfig(logger.NewNopLogger(), "")
+	assert.NoError(err)
+	assert.Equal(5, cfg.MaxStringLength)
+}
+
+func Test_LoadConfig_UnknownKey(t *testing.T) {
+	assert := assert.New(t)
+	path := writeConfigFile(t, "config.yaml", "port: 8080\nmax_strng_length: 5\n")
+
+	_, err := LoadConfig(logger.NewNopLogger(), path)
+	assert.EqualError(err, path+`:2: unknown key "max_strng_length"`)
+}
+
+func Test_LoadConfig_InvalidValue(t *testing.T) {
+	assert := assert.New(t)
+	path := writeConfigFile(t, "config.toml", "\nmax_string_length = \"many\"\n")
+
+	_, err := LoadConfig(logger.NewNopLogger(), path)
+	assert.EqualError(err, path+`:2: MAX_STRING_LENGTH: "many" is not an integer`)
+}
+
+func Test_LoadConfig_UnsupportedExtension(t *testing.T) {
+	path := writeConfigFile(t, "config.json", "{}")
+	_, err := LoadConfig(logger.NewNopLogger(), path)
+	assert.Error(t, err)
+}
+
+func Test_Validate_ReportsSources(t *testing.T) {
+	assert := assert.New(t)
+	path := writeConfigFile(t, "config.yaml", "port: 8080\nmax_string_length: 0\n")
+
+	cfg, err := LoadConfig(logger.NewNopLogger(), path)
+	assert.NoError(err)
+	assert.NoError(cfg.SetFrom("PORT", "70000", "--port"))
+
+	err = cfg.Validate()
+	assert.ErrorContains(err, `--port: PORT: "70000" is not a valid port`)
+	assert.ErrorContains(err, path+":2: MAX_STRING_LENGTH: must be strictly positive")
+}
+
+func Test_Redacted(t *testing.T) {
+	assert := assert.New(t)
+	cfg := Config{AdminAPIKey: "secret"}
+	assert.Equal("REDACTED", cfg.Redacted().AdminAPIKey)
+	assert.Equal("secret", cfg.AdminAPIKey)
+}
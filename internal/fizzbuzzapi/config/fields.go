@@ -0,0 +1,97 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// Prefix of the environment variables holding the config
+const EnvPrefix = "FBAPI_"
+
+// Field describes a config setting, as declared by the tags of the Config struct
+type Field struct {
+	Name    string // Name of the setting, the environment variable without its prefix, e.g. "MAX_STRING_LENGTH"
+	Default string
+	Secret  bool // Secrets are masked by Redacted
+
+	index int
+}
+
+func (f Field) Env() string {
+	return EnvPrefix + f.Name
+}
+
+// Fields lists the settings of the Config struct in declaration order
+func Fields() []Field {
+	t := reflect.TypeFor[Config]()
+	fields := make([]Field, 0, t.NumField())
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		name := sf.Tag.Get("envconfig")
+		if name == "" {
+			continue
+		}
+		fields = append(fields, Field{
+			Name:    name,
+			Default: sf.Tag.Get("default"),
+			Secret:  sf.Tag.Get("secret") == "true",
+			index:   i,
+		})
+	}
+	return fields
+}
+
+func lookupField(name string) (Field, error) {
+	for _, f := range Fields() {
+		if f.Name == name {
+			return f, nil
+		}
+	}
+	return Field{}, fmt.Errorf("unknown config field %q", name)
+}
+
+// Get returns the value of a setting formatted as it would be written in its environment variable
+func (cfg *Config) Get(name string) string {
+	f, err := lookupField(name)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprint(reflect.ValueOf(cfg).Elem().Field(f.index).Interface())
+}
+
+// Set parses value as it would be read from the environment variable of a setting and assigns it
+func (cfg *Config) Set(name, value string) error {
+	f, err := lookupField(name)
+	if err != nil {
+		return err
+	}
+
+	field := reflect.ValueOf(cfg).Elem().Field(f.index)
+	switch field.Interface().(type) {
+	case string:
+		field.SetString(value)
+	case int:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%s: %q is not an integer", name, value)
+		}
+		field.SetInt(int64(n))
+	case bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%s: %q is not a boolean", name, value)
+		}
+		field.SetBool(b)
+	case time.Duration:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("%s: %q is not a duration", name, value)
+		}
+		field.SetInt(int64(d))
+	default:
+		return fmt.Errorf("%s: unsupported field type %s", name, field.Type())
+	}
+	return nil
+}
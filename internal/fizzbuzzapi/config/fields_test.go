@@ -0,0 +1,70 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Fields(t *testing.T) {
+	assert := assert.New(t)
+	fields := map[string]Field{}
+	for _, f := range Fields() {
+		fields[f.Name] = f
+	}
+
+	assert.Equal("PORT", Fields()[0].Name)
+	assert.Equal("FBAPI_MAX_STRING_LENGTH", fields["MAX_STRING_LENGTH"].Env())
+	assert.Equal("30", fields["MAX_STRING_LENGTH"].Default)
+	assert.True(fields["ADMIN_API_KEY"].Secret)
+	assert.False(fields["PORT"].Secret)
+	assert.NotContains(fields, "sources")
+}
+
+func Test_Set(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		get     func(cfg *Config) any
+		want    any
+		wantErr string
+	}{
+		{"PORT", "8080", func(cfg *Config) any { return cfg.Port }, "8080", ""},
+		{"MAX_STRING_LENGTH", "12", func(cfg *Config) any { return cfg.MaxStringLength }, 12, ""},
+		{"CONFIG_RELOAD_INTERVAL", "1m", func(cfg *Config) any { return cfg.ConfigReloadInterval }, time.Minute, ""},
+		{"MAX_STRING_LENGTH", "twelve", nil, nil, `MAX_STRING_LENGTH: "twelve" is not an integer`},
+		{"CONFIG_RELOAD_INTERVAL", "5", nil, nil, `CONFIG_RELOAD_INTERVAL: "5" is not a duration`},
+		{"UNKNOWN", "1", nil, nil, `unknown config field "UNKNOWN"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+"="+tt.value, func(t *testing.T) {
+			assert := assert.New(t)
+			var cfg Config
+			err := cfg.Set(tt.name, tt.value)
+			if tt.wantErr != "" {
+				assert.EqualError(err, tt.wantErr)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tt.want, tt.get(&cfg))
+		})
+	}
+}
+
+func Test_Get(t *testing.T) {
+	assert := assert.New(t)
+	cfg := Config{Port: "8080", MaxFizzBuzzLimit: 100, ConfigReloadInterval: 5 * time.Second}
+	assert.Equal("8080", cfg.Get("PORT"))
+	assert.Equal("100", cfg.Get("MAX_FIZZBUZZ_LIMIT"))
+	assert.Equal("5s", cfg.Get("CONFIG_RELOAD_INTERVAL"))
+	assert.Equal("", cfg.Get("UNKNOWN"))
+
+	// Get and Set round trip
+	var copy Config
+	for _, f := range Fields() {
+		assert.NoError(copy.Set(f.Name, cfg.Get(f.Name)), f.Name)
+	}
+	assert.Equal(cfg, copy)
+}
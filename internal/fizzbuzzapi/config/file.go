@@ -0,0 +1,131 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
+	"github.com/pelletier/go-toml/v2/unstable"
+)
+
+// fileSetting is a key/value pair read from a config file, with its location for error reporting
+type fileSetting struct {
+	key   string
+	value string
+	line  int
+}
+
+// FileKey returns the key of a setting in config files, its name in lower case: "max_string_length"
+func (f Field) FileKey() string {
+	return strings.ToLower(f.Name)
+}
+
+// readFile parses a flat YAML or TOML config file, picked by extension
+func readFile(path string) ([]fileSetting, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return parseYAML(path, data)
+	case ".toml":
+		return parseTOML(path, data)
+	default:
+		return nil, fmt.Errorf("%s: unsupported config file extension, expected .yaml, .yml or .toml", path)
+	}
+}
+
+func parseYAML(path string, data []byte) ([]fileSetting, error) {
+	file, err := parser.ParseBytes(data, 0)
+	if err != nil {
+		var yerr yaml.Error
+		if errors.As(err, &yerr) && yerr.GetToken() != nil {
+			return nil, fmt.Errorf("%s:%d: %s", path, yerr.GetToken().Position.Line, yerr.GetMessage())
+		}
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	var settings []fileSetting
+	for _, doc := range file.Docs {
+		if doc.Body == nil {
+			continue
+		}
+		mapping, ok := doc.Body.(*ast.MappingNode)
+		if !ok {
+			if single, ok := doc.Body.(*ast.MappingValueNode); ok {
+				mapping = &ast.MappingNode{Values: []*ast.MappingValueNode{single}}
+			} else {
+				return nil, fmt.Errorf("%s:%d: expected a mapping of settings", path, doc.Body.GetToken().Position.Line)
+			}
+		}
+
+		for _, kv := range mapping.Values {
+			line := kv.Key.GetToken().Position.Line
+			var value any
+			if err := yaml.NodeToValue(kv.Value, &value); err != nil {
+				return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+			}
+			switch value.(type) {
+			case map[string]any, []any:
+				return nil, fmt.Errorf("%s:%d: %s: nested values are not supported", path, line, kv.Key.String())
+			}
+			str := ""
+			if value != nil {
+				str = fmt.Sprint(value)
+			}
+			settings = append(settings, fileSetting{key: kv.Key.String(), value: str, line: line})
+		}
+	}
+	return settings, nil
+}
+
+func parseTOML(path string, data []byte) ([]fileSetting, error) {
+	p := unstable.Parser{}
+	p.Reset(data)
+
+	var settings []fileSetting
+	for p.NextExpression() {
+		expr := p.Expression()
+		if expr.Kind != unstable.KeyValue && expr.Kind != unstable.Table && expr.Kind != unstable.ArrayTable {
+			continue
+		}
+
+		var parts []string
+		line := 0
+		keys := expr.Key()
+		for keys.Next() {
+			if line == 0 {
+				line = p.Shape(keys.Node().Raw).Start.Line
+			}
+			parts = append(parts, string(keys.Node().Data))
+		}
+		key := strings.Join(parts, ".")
+
+		if expr.Kind != unstable.KeyValue {
+			return nil, fmt.Errorf("%s:%d: [%s]: tables are not supported", path, line, key)
+		}
+		value := expr.Value()
+		switch value.Kind {
+		case unstable.String, unstable.Bool, unstable.Float, unstable.Integer:
+			settings = append(settings, fileSetting{key: key, value: string(value.Data), line: line})
+		default:
+			return nil, fmt.Errorf("%s:%d: %s: unsupported %s value", path, line, key, value.Kind)
+		}
+	}
+
+	if err := p.Error(); err != nil {
+		var perr *unstable.ParserError
+		if errors.As(err, &perr) {
+			return nil, fmt.Errorf("%s:%d: %s", path, p.Shape(p.Range(perr.Highlight)).Start.Line, perr.Message)
+		}
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return settings, nil
+}
@@ -0,0 +1,133 @@
+package controllers
+
+import (
+	"fizzbuzz-api/internal/fizzbuzzapi/types"
+	"math/big"
+)
+
+// Positions and limits fitting in int64 are computed with machine integers, larger ones with math/big
+
+// ValueAt returns the value at position N of the sequence in O(1) for int64 positions. N is not bound by the max limit.
+func (ctrl *FizzBuzzController) ValueAt(req types.FizzBuzzAtRequest) (types.FizzBuzzAtResponse, error) {
+	if req.N.Sign() <= 0 || req.Int1 <= 0 || req.Int2 <= 0 {
+		return types.FizzBuzzAtResponse{}, ErrNegativeParameter
+	}
+	str1, str2, err := ctrl.validateStrings(req.Str1, req.Str2)
+	if err != nil {
+		return types.FizzBuzzAtResponse{}, err
+	}
+
+	var value string
+	if req.N.IsInt64() {
+		value = valueAt(req.Int1, req.Int2, str1, str2, req.N.Int64())
+	} else {
+		value = bigValueAt(req.Int1, req.Int2, str1, str2, &req.N.Int)
+	}
+	return types.FizzBuzzAtResponse{N: req.N, Value: value}, nil
+}
+
+// Count returns how many positions up to the limit hold each kind of value, by inclusion-exclusion.
+// The limit is not bound by the max limit.
+func (ctrl *FizzBuzzController) Count(req types.FizzBuzzCountRequest) (types.FizzBuzzCountResponse, error) {
+	if req.Limit.Sign() <= 0 || req.Int1 <= 0 || req.Int2 <= 0 {
+		return types.FizzBuzzCountResponse{}, ErrNegativeParameter
+	}
+	if req.Limit.IsInt64() {
+		return count(req.Limit.Int64(), req.Int1, req.Int2), nil
+	}
+
+	limit := &req.Limit.Int
+	int1, int2 := big.NewInt(req.Int1), big.NewInt(req.Int2)
+	l := new(big.Int).Mul(new(big.Int).Quo(int1, new(big.Int).GCD(nil, nil, int1, int2)), int2)
+
+	var resp types.FizzBuzzCountResponse
+	resp.Limit.Set(limit)
+	resp.Str1Str2.Quo(limit, l)
+	resp.Str1.Quo(limit, int1)
+	resp.Str2.Quo(limit, int2)
+	resp.Numbers.Sub(limit, &resp.Str1.Int)
+	resp.Numbers.Sub(&resp.Numbers.Int, &resp.Str2.Int)
+	resp.Numbers.Add(&resp.Numbers.Int, &resp.Str1Str2.Int)
+	resp.Str1.Sub(&resp.Str1.Int, &resp.Str1Str2.Int)
+	resp.Str2.Sub(&resp.Str2.Int, &resp.Str1Str2.Int)
+	return resp, nil
+}
+
+func count(limit, int1, int2 int64) types.FizzBuzzCountResponse {
+	// An lcm overflowing int64 exceeds any limit, no position is a multiple of both
+	var both int64
+	if l, ok := lcm(int1, int2); ok {
+		both = limit / l
+	}
+	str1 := limit/int1 - both
+	str2 := limit/int2 - both
+
+	return types.FizzBuzzCountResponse{
+		Limit:    types.NewBigInt(limit),
+		Numbers:  types.NewBigInt(limit - str1 - str2 - both),
+		Str1:     types.NewBigInt(str1),
+		Str2:     types.NewBigInt(str2),
+		Str1Str2: types.NewBigInt(both),
+	}
+}
+
+// Window returns Size values from position Start, which is not bound by the max limit. Size is.
+func (ctrl *FizzBuzzController) Window(req types.FizzBuzzWindowRequest) (types.FizzBuzzWindowResponse, error) {
+	if req.Start.Sign() <= 0 || req.Size <= 0 || req.Int1 <= 0 || req.Int2 <= 0 {
+		return types.FizzBuzzWindowResponse{}, ErrNegativeParameter
+	}
+	if req.Size > ctrl.Limits().MaxLimit {
+		return types.FizzBuzzWindowResponse{}, ErrLimitExceeded
+	}
+	str1, str2, err := ctrl.validateStrings(req.Str1, req.Str2)
+	if err != nil {
+		return types.FizzBuzzWindowResponse{}, err
+	}
+
+	result := make([]string, 0, req.Size)
+	end := new(big.Int).Add(&req.Start.Int, big.NewInt(int64(req.Size-1)))
+	if end.IsInt64() {
+		start := req.Start.Int64()
+		for k := range int64(req.Size) {
+			result = append(result, valueAt(req.Int1, req.Int2, str1, str2, start+k))
+		}
+		return types.FizzBuzzWindowResponse{Start: req.Start, Result: result}, nil
+	}
+
+	// Divisibility is tracked through the remainders of the start, positions only being formatted
+	i := new(big.Int).Set(&req.Start.Int)
+	one := big.NewInt(1)
+	r1 := new(big.Int).Mod(i, big.NewInt(req.Int1)).Int64()
+	r2 := new(big.Int).Mod(i, big.NewInt(req.Int2)).Int64()
+	for range req.Size {
+		switch {
+		case r1 == 0 && r2 == 0:
+			result = append(result, str1+str2)
+		case r1 == 0:
+			result = append(result, str1)
+		case r2 == 0:
+			result = append(result, str2)
+		default:
+			result = append(result, i.String())
+		}
+		i.Add(i, one)
+		r1, r2 = (r1+1)%req.Int1, (r2+1)%req.Int2
+	}
+	return types.FizzBuzzWindowResponse{Start: req.Start, Result: result}, nil
+}
+
+func bigValueAt(int1, int2 int64, str1, str2 string, n *big.Int) string {
+	var r big.Int
+	divisible1 := r.Mod(n, big.NewInt(int1)).Sign() == 0
+	divisible2 := r.Mod(n, big.NewInt(int2)).Sign() == 0
+	switch {
+	case divisible1 && divisible2:
+		return str1 + str2
+	case divisible1:
+		return str1
+	case divisible2:
+		return str2
+	default:
+		return n.String()
+	}
+}
@@ -0,0 +1,165 @@
+package controllers
+
+import (
+	"fizzbuzz-api/internal/fizzbuzzapi/types"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ValueAt(t *testing.T) {
+	ctrl := NewFizzBuzzController(types.FizzBuzzLimits{MaxLimit: 100, MaxStringLength: 10}, &mockLogger{})
+
+	for _, divisors := range [][2]int{{3, 5}, {2, 4}, {6, 6}, {1, 7}} {
+		req := types.FizzBuzzRequest{Int1: divisors[0], Int2: divisors[1], Limit: 100, Str1: "fizz", Str2: "buzz"}
+		full, _ := ctrl.GenerateFizzBuzz(req)
+		for i, value := range full.Result {
+			at, err := ctrl.ValueAt(types.FizzBuzzAtRequest{N: types.NewBigInt(int64(i + 1)), Int1: int64(req.Int1), Int2: int64(req.Int2), Str1: "fizz", Str2: "buzz"})
+			assert.NoError(t, err)
+			assert.Equal(t, value, at.Value, "%+v at %d", req, i+1)
+		}
+	}
+}
+
+func Test_ValueAt_BeyondMaxLimit(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := NewFizzBuzzController(types.FizzBuzzLimits{MaxLimit: 100, MaxStringLength: 10}, &mockLogger{})
+
+	tests := []struct {
+		n    int64
+		want string
+	}{
+		{987654321, "fizz"},
+		{987654320, "buzz"},
+		{987654330, "fizzbuzz"},
+		{987654322, "987654322"},
+		{math.MaxInt64, "9223372036854775807"},
+	}
+	for _, tt := range tests {
+		at, err := ctrl.ValueAt(types.FizzBuzzAtRequest{N: types.NewBigInt(tt.n), Int1: 3, Int2: 5, Str1: "fizz", Str2: "buzz"})
+		assert.NoError(err)
+		assert.Equal(tt.want, at.Value)
+	}
+
+	_, err := ctrl.ValueAt(types.FizzBuzzAtRequest{N: types.NewBigInt(0), Int1: 3, Int2: 5, Str1: "fizz", Str2: "buzz"})
+	assert.Equal(ErrNegativeParameter, err)
+	_, err = ctrl.ValueAt(types.FizzBuzzAtRequest{N: types.NewBigInt(1), Int1: 3, Int2: 5, Str1: "fizzfizzfizz", Str2: "buzz"})
+	assert.Equal(ErrStringLengthExceeded, err)
+}
+
+func Test_Count(t *testing.T) {
+	ctrl := NewFizzBuzzController(types.FizzBuzzLimits{MaxLimit: 1000, MaxStringLength: 10}, &mockLogger{})
+
+	// Counts must match the generated sequence
+	for _, divisors := range [][2]int{{3, 5}, {2, 4}, {6, 6}, {1, 7}, {4, 6}, {999, 1000}} {
+		for _, limit := range []int{1, 14, 15, 16, 999, 1000} {
+			req := types.FizzBuzzRequest{Int1: divisors[0], Int2: divisors[1], Limit: limit, Str1: "a", Str2: "b"}
+			full, _ := ctrl.GenerateFizzBuzz(req)
+			counts := map[string]int64{}
+			for _, value := range full.Result {
+				switch value {
+				case "a", "b", "ab":
+					counts[value]++
+				default:
+					counts["number"]++
+				}
+			}
+
+			count, err := ctrl.Count(types.FizzBuzzCountRequest{Limit: types.NewBigInt(int64(limit)), Int1: int64(req.Int1), Int2: int64(req.Int2)})
+			assert.NoError(t, err)
+			assert.Equal(t, map[string]int64{"number": counts["number"], "a": counts["a"], "b": counts["b"], "ab": counts["ab"]},
+				map[string]int64{"number": count.Numbers.Int64(), "a": count.Str1.Int64(), "b": count.Str2.Int64(), "ab": count.Str1Str2.Int64()}, "%+v", req)
+		}
+	}
+}
+
+func Test_Count_Overflow(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := NewFizzBuzzController(types.FizzBuzzLimits{MaxLimit: 100, MaxStringLength: 10}, &mockLogger{})
+
+	// lcm(MaxInt64, MaxInt64-1) overflows, no position is a multiple of both
+	count, err := ctrl.Count(types.FizzBuzzCountRequest{Limit: types.NewBigInt(math.MaxInt64), Int1: math.MaxInt64, Int2: math.MaxInt64 - 1})
+	assert.NoError(err)
+	assert.Equal([]int64{math.MaxInt64 - 2, 1, 1, 0}, []int64{count.Numbers.Int64(), count.Str1.Int64(), count.Str2.Int64(), count.Str1Str2.Int64()})
+
+	count, err = ctrl.Count(types.FizzBuzzCountRequest{Limit: types.NewBigInt(math.MaxInt64), Int1: 3, Int2: 5})
+	assert.NoError(err)
+	assert.Equal(int64(math.MaxInt64/15), count.Str1Str2.Int64())
+	total := new(big.Int).Add(&count.Numbers.Int, &count.Str1.Int)
+	total.Add(total, &count.Str2.Int).Add(total, &count.Str1Str2.Int)
+	assert.Equal(int64(math.MaxInt64), total.Int64())
+
+	_, err = ctrl.Count(types.FizzBuzzCountRequest{Limit: types.NewBigInt(10), Int1: -3, Int2: 5})
+	assert.Equal(ErrNegativeParameter, err)
+}
+
+func Test_Analytics_BeyondInt64(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := NewFizzBuzzController(types.FizzBuzzLimits{MaxLimit: 100, MaxStringLength: 10}, &mockLogger{})
+	n, _ := types.ParseBigInt("1000000000000000000000000000000") // 10^30, a multiple of 5 but not 3
+
+	at, err := ctrl.ValueAt(types.FizzBuzzAtRequest{N: n, Int1: 3, Int2: 5, Str1: "fizz", Str2: "buzz"})
+	assert.NoError(err)
+	assert.Equal("buzz", at.Value)
+	next, _ := types.ParseBigInt("1000000000000000000000000000002")
+	at, _ = ctrl.ValueAt(types.FizzBuzzAtRequest{N: next, Int1: 3, Int2: 5, Str1: "fizz", Str2: "buzz"})
+	assert.Equal("fizz", at.Value)
+
+	count, err := ctrl.Count(types.FizzBuzzCountRequest{Limit: n, Int1: 3, Int2: 5})
+	assert.NoError(err)
+	assert.Equal("66666666666666666666666666666", count.Str1Str2.String())
+	assert.Equal("266666666666666666666666666667", count.Str1.String())
+	assert.Equal("133333333333333333333333333334", count.Str2.String())
+	assert.Equal("533333333333333333333333333333", count.Numbers.String())
+
+	// Windows crossing int64 give the values of positions computed one by one
+	start := types.NewBigInt(math.MaxInt64 - 2)
+	window, err := ctrl.Window(types.FizzBuzzWindowRequest{Start: start, Size: 6, Int1: 3, Int2: 5, Str1: "fizz", Str2: "buzz"})
+	assert.NoError(err)
+	var want []string
+	for k := range int64(6) {
+		position := new(big.Int).Add(&start.Int, big.NewInt(k))
+		at, _ := ctrl.ValueAt(types.FizzBuzzAtRequest{N: types.BigInt{Int: *position}, Int1: 3, Int2: 5, Str1: "fizz", Str2: "buzz"})
+		want = append(want, at.Value)
+	}
+	assert.Equal(want, window.Result)
+	assert.Equal([]string{"buzz", "fizz", "9223372036854775807", "9223372036854775808", "fizz", "buzz"}, window.Result)
+}
+
+func Test_Window(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := NewFizzBuzzController(types.FizzBuzzLimits{MaxLimit: 100, MaxStringLength: 10}, &mockLogger{})
+
+	full, _ := ctrl.GenerateFizzBuzz(types.FizzBuzzRequest{Int1: 3, Int2: 5, Limit: 100, Str1: "fizz", Str2: "buzz"})
+	window, err := ctrl.Window(types.FizzBuzzWindowRequest{Start: types.NewBigInt(11), Size: 20, Int1: 3, Int2: 5, Str1: "fizz", Str2: "buzz"})
+	assert.NoError(err)
+	assert.Equal(full.Result[10:30], window.Result)
+
+	_, err = ctrl.Window(types.FizzBuzzWindowRequest{Start: types.NewBigInt(1), Size: 101, Int1: 3, Int2: 5, Str1: "fizz", Str2: "buzz"})
+	assert.Equal(ErrLimitExceeded, err, "The size of windows is bound by the max limit")
+	_, err = ctrl.Window(types.FizzBuzzWindowRequest{Start: types.NewBigInt(0), Size: 1, Int1: 3, Int2: 5, Str1: "fizz", Str2: "buzz"})
+	assert.Equal(ErrNegativeParameter, err)
+}
+
+func Test_PositionalTemplates(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := NewFizzBuzzController(types.FizzBuzzLimits{MaxLimit: 100, MaxStringLength: 10}, &mockLogger{})
+
+	_, err := ctrl.ValueAt(types.FizzBuzzAtRequest{N: types.NewBigInt(3), Int1: 3, Int2: 5, Str1: "f#{n}", Str2: "buzz"})
+	assert.ErrorIs(err, ErrPositionalTemplate)
+	assert.Equal(ErrCodeInvalidParameter, ErrorCode(err))
+	_, err = ctrl.Window(types.FizzBuzzWindowRequest{Start: types.NewBigInt(1), Size: 5, Int1: 3, Int2: 5, Str1: "fizz", Str2: "{n:hex}"})
+	assert.ErrorIs(err, ErrPositionalTemplate)
+	_, err = ctrl.ValueAt(types.FizzBuzzAtRequest{N: types.NewBigInt(3), Int1: 3, Int2: 5, Str1: "f{x}", Str2: "buzz"})
+	assert.ErrorIs(err, ErrInvalidTemplate)
+
+	// Escaped braces are written as generate writes them
+	at, err := ctrl.ValueAt(types.FizzBuzzAtRequest{N: types.NewBigInt(15), Int1: 3, Int2: 5, Str1: "{{f}}", Str2: "b"})
+	assert.NoError(err)
+	assert.Equal("{f}b", at.Value)
+	window, err := ctrl.Window(types.FizzBuzzWindowRequest{Start: types.NewBigInt(1), Size: 3, Int1: 3, Int2: 5, Str1: "{{f}}", Str2: "b"})
+	assert.NoError(err)
+	assert.Equal([]string{"1", "2", "{f}"}, window.Result)
+}
@@ -0,0 +1,82 @@
+package controllers
+
+import (
+	"fizzbuzz-api/internal/fizzbuzzapi/types"
+	"iter"
+	"time"
+)
+
+// ExplainFizzBuzz validates the request like GenerateFizzBuzz, and returns the values of the sequence along with
+// the rules they matched, and a summary of the whole sequence
+func (ctrl *FizzBuzzController) ExplainFizzBuzz(req types.FizzBuzzRequest) (types.FizzBuzzExplainResponse, error) {
+	seq, summary, err := ctrl.StreamExplained(req)
+	if err != nil {
+		return types.FizzBuzzExplainResponse{}, err
+	}
+
+	start := time.Now()
+	result := make([]types.FizzBuzzExplainedValue, 0, sequenceLength(req))
+	for v := range seq {
+		result = append(result, v)
+	}
+	duration := time.Since(start)
+	ctrl.log.Info("fizzBuzz explained", "length", len(result), "period", summary.Period, "duration_ms", duration.Milliseconds())
+
+	return types.FizzBuzzExplainResponse{
+		Result:   result,
+		Summary:  *summary,
+		Duration: duration.Milliseconds(),
+	}, nil
+}
+
+// StreamExplained validates the request like GenerateFizzBuzz, and returns the sequence as an iterator of values
+// along with the rules they matched. The summary holds the period from the start, and counts values as
+// they are consumed, so it is complete once the iterator is exhausted.
+func (ctrl *FizzBuzzController) StreamExplained(req types.FizzBuzzRequest) (iter.Seq[types.FizzBuzzExplainedValue], *types.FizzBuzzExplainSummary, error) {
+	f, err := ctrl.validate(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	summary := &types.FizzBuzzExplainSummary{
+		Period:           patternPeriod(req),
+		Counts:           map[string]int{types.TokenNumber: 0, types.TokenStr1: 0, types.TokenStr2: 0, types.TokenStr1Str2: 0},
+		FirstOccurrences: map[string]int{},
+	}
+	seq := func(yield func(types.FizzBuzzExplainedValue) bool) {
+		index := 0
+		for i := range positions(req) {
+			index++
+			v := explain(req, index, i)
+			v.Value = f.value(i)
+			summary.Record(v)
+			if !yield(v) {
+				return
+			}
+		}
+	}
+	return seq, summary, nil
+}
+
+// explain returns the rules matched by the number at index, without its value
+func explain(req types.FizzBuzzRequest, index, i int) types.FizzBuzzExplainedValue {
+	v := types.FizzBuzzExplainedValue{Index: index, Number: i, Rules: []string{}}
+	if i%req.Int1 == 0 {
+		v.Rules = append(v.Rules, types.RuleInt1)
+	}
+	if i%req.Int2 == 0 {
+		v.Rules = append(v.Rules, types.RuleInt2)
+	}
+	switch len(v.Rules) {
+	case 2:
+		v.Token = types.TokenStr1Str2
+	case 0:
+		v.Token = types.TokenNumber
+	default:
+		v.Token = types.TokenStr1
+		if v.Rules[0] == types.RuleInt2 {
+			v.Token = types.TokenStr2
+		}
+	}
+	return v
+}
@@ -0,0 +1,90 @@
+package controllers
+
+import (
+	"fizzbuzz-api/internal/fizzbuzzapi/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ExplainFizzBuzz(t *testing.T) {
+	ctrl := NewFizzBuzzController(types.FizzBuzzLimits{
+		MaxLimit:          1000,
+		MaxStringLength:   100,
+		MaxTemplateLength: 100,
+		MaxExpandedLength: 100,
+	}, &mockLogger{})
+	ptr := func(i int) *int { return &i }
+
+	// Explained values must match the generated sequence, and the summary must match the compact pattern
+	for _, req := range []types.FizzBuzzRequest{
+		{Int1: 3, Int2: 5, Limit: 100, Str1: "Fizz", Str2: "Buzz"},
+		{Int1: 2, Int2: 4, Limit: 7, Str1: "Fizz", Str2: "Buzz"},
+		{Int1: 3, Int2: 5, Start: ptr(-10), End: ptr(20), Step: ptr(4), Str1: "Fizz", Str2: "Buzz"},
+		{Int1: 3, Int2: 5, Limit: 10, Str1: "f{n}", Str2: "Buzz", NumberFormat: "roman"},
+	} {
+		full, err := ctrl.GenerateFizzBuzz(req)
+		assert.NoError(t, err)
+		explained, err := ctrl.ExplainFizzBuzz(req)
+		assert.NoError(t, err)
+
+		counts := map[string]int{}
+		for k, v := range explained.Result {
+			assert.Equal(t, k+1, v.Index)
+			assert.Equal(t, full.Result[k], v.Value, "%+v", req)
+			counts[v.Token]++
+		}
+		assert.Len(t, explained.Result, len(full.Result))
+		for token, count := range explained.Summary.Counts {
+			assert.Equal(t, counts[token], count, "%s of %+v", token, req)
+		}
+
+		if req.NumberFormat == "" {
+			compact, err := ctrl.GenerateCompact(req)
+			assert.NoError(t, err)
+			assert.Equal(t, compact.Period, explained.Summary.Period)
+		}
+	}
+}
+
+func Test_ExplainFizzBuzz_Summary(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := NewFizzBuzzController(types.FizzBuzzLimits{
+		MaxLimit:        100,
+		MaxStringLength: 100,
+	}, &mockLogger{})
+
+	explained, err := ctrl.ExplainFizzBuzz(types.FizzBuzzRequest{Int1: 3, Int2: 5, Limit: 14, Str1: "Fizz", Str2: "Buzz"})
+	assert.NoError(err)
+	assert.Equal(types.FizzBuzzExplainedValue{Index: 5, Number: 5, Rules: []string{types.RuleInt2}, Token: types.TokenStr2, Value: "Buzz"}, explained.Result[4])
+	assert.Equal(types.FizzBuzzExplainSummary{
+		Period:           14,
+		Counts:           map[string]int{types.TokenNumber: 8, types.TokenStr1: 4, types.TokenStr2: 2, types.TokenStr1Str2: 0},
+		FirstOccurrences: map[string]int{types.TokenNumber: 1, types.TokenStr1: 3, types.TokenStr2: 5},
+	}, explained.Summary)
+
+	_, err = ctrl.ExplainFizzBuzz(types.FizzBuzzRequest{Int1: 3, Int2: 5, Limit: 101, Str1: "Fizz", Str2: "Buzz"})
+	assert.Equal(ErrLimitExceeded, err)
+}
+
+func Test_StreamExplained(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := NewFizzBuzzController(types.FizzBuzzLimits{
+		MaxLimit:        100,
+		MaxStringLength: 100,
+	}, &mockLogger{})
+
+	seq, summary, err := ctrl.StreamExplained(types.FizzBuzzRequest{Int1: 3, Int2: 5, Limit: 100, Str1: "Fizz", Str2: "Buzz"})
+	assert.NoError(err)
+	assert.Equal(15, summary.Period)
+
+	// The summary counts the values consumed so far
+	for v := range seq {
+		if v.Index == 15 {
+			assert.Equal([]string{types.RuleInt1, types.RuleInt2}, v.Rules)
+			break
+		}
+	}
+	assert.Equal(1, summary.Counts[types.TokenStr1Str2])
+	assert.Equal(15, summary.FirstOccurrences[types.TokenStr1Str2])
+}
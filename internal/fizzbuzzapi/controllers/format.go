@@ -0,0 +1,297 @@
+package controllers
+
+import (
+	"fizzbuzz-api/internal/fizzbuzzapi/i18n"
+	"fizzbuzz-api/internal/fizzbuzzapi/types"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Number formats of FizzBuzzRequest.NumberFormat, also accepted by {n:format} placeholders.
+// Zero-padded decimals are written as their width with a leading 0, like in printf: "05".
+const (
+	NumberFormatDecimal = "decimal"
+	NumberFormatHex     = "hex"
+	NumberFormatBinary  = "binary"
+	NumberFormatRoman   = "roman"
+	NumberFormatWords   = "words"
+)
+
+// Roman numerals only go from I to MMMCMXCIX
+const (
+	minRoman       = 1
+	maxRoman       = 3999
+	maxRomanLength = len("MMMDCCCLXXXVIII")
+)
+
+var errRomanRange = fmt.Errorf("%w: roman numerals only cover %d to %d", ErrInvalidNumberFormat, minRoman, maxRoman)
+
+// numberFormat writes integers in one of the number formats
+type numberFormat struct {
+	name   string
+	width  int         // Minimum width of zero-padded decimals
+	locale i18n.Locale // Language of words
+}
+
+var decimalFormat = numberFormat{name: NumberFormatDecimal}
+
+func parseNumberFormat(s string, locale i18n.Locale) (numberFormat, error) {
+	switch s {
+	case "", NumberFormatDecimal:
+		return decimalFormat, nil
+	case NumberFormatHex, NumberFormatBinary, NumberFormatRoman:
+		return numberFormat{name: s}, nil
+	case NumberFormatWords:
+		return numberFormat{name: s, locale: locale}, nil
+	}
+	if len(s) >= 2 && s[0] == '0' && strings.Trim(s, "0123456789") == "" {
+		if width, err := strconv.Atoi(s[1:]); err == nil && width > 0 {
+			return numberFormat{name: NumberFormatDecimal, width: width}, nil
+		}
+	}
+	return numberFormat{}, fmt.Errorf("%w %q, expected decimal, hex, binary, roman, words or a zero-padded width such as 05", ErrInvalidNumberFormat, s)
+}
+
+func (f numberFormat) format(i int) string {
+	switch f.name {
+	case NumberFormatHex:
+		return strconv.FormatInt(int64(i), 16)
+	case NumberFormatBinary:
+		return strconv.FormatInt(int64(i), 2)
+	case NumberFormatRoman:
+		return romanNumeral(i)
+	case NumberFormatWords:
+		return i18n.NumberWords(f.locale, i)
+	}
+	if f.width > 0 {
+		return fmt.Sprintf("%0*d", f.width, i)
+	}
+	return strconv.Itoa(i)
+}
+
+// check reports values from lo to hi the format cannot write
+func (f numberFormat) check(lo, hi int) error {
+	if f.name == NumberFormatRoman && (lo < minRoman || hi > maxRoman) {
+		return errRomanRange
+	}
+	return nil
+}
+
+// maxLength returns an upper bound of the length of the values from lo to hi once formatted
+func (f numberFormat) maxLength(lo, hi int) int {
+	switch f.name {
+	case NumberFormatRoman:
+		return maxRomanLength
+	case NumberFormatWords:
+		return i18n.MaxNumberWordsLength(f.locale, lo, hi)
+	}
+	// Other lengths grow with the absolute value, the widest values are the bounds. Zero-padded decimals are
+	// measured rather than written, their width being bound by the expanded length only.
+	if f.name == NumberFormatDecimal {
+		return max(f.width, len(strconv.Itoa(lo)), len(strconv.Itoa(hi)))
+	}
+	return max(len(f.format(lo)), len(f.format(hi)))
+}
+
+// template is a replacement string, whose {n} placeholders are replaced by the number they stand for.
+// {n} writes the number in the number format of the request, {n:format} in another one, {{ and }} write braces.
+type template []templatePart
+
+type templatePart struct {
+	literal string
+	number  *numberFormat // Set for placeholders
+}
+
+func parseTemplate(s string, defaultFormat numberFormat) (template, error) {
+	var (
+		t       template
+		literal strings.Builder
+	)
+	invalid := func(reason string, offset int) error {
+		return fmt.Errorf("%w %q: %s at offset %d", ErrInvalidTemplate, s, reason, offset)
+	}
+	for i := 0; i < len(s); i++ {
+		switch {
+		case strings.HasPrefix(s[i:], "{{"), strings.HasPrefix(s[i:], "}}"):
+			literal.WriteByte(s[i])
+			i++
+		case s[i] == '}':
+			return nil, invalid("unmatched }", i)
+		case s[i] == '{':
+			end := strings.IndexByte(s[i:], '}')
+			if end < 0 {
+				return nil, invalid("unterminated placeholder", i)
+			}
+			name, format, hasFormat := strings.Cut(s[i+1:i+end], ":")
+			if name != "n" {
+				return nil, invalid("unknown placeholder "+s[i:i+end+1]+", expected {n} or {n:format}", i)
+			}
+			f := defaultFormat
+			if hasFormat {
+				var err error
+				if f, err = parseNumberFormat(format, defaultFormat.locale); err != nil {
+					return nil, fmt.Errorf("%w %q at offset %d: %w", ErrInvalidTemplate, s, i, err)
+				}
+			}
+			if literal.Len() > 0 {
+				t = append(t, templatePart{literal: literal.String()})
+				literal.Reset()
+			}
+			t = append(t, templatePart{number: &f})
+			i += end
+		default:
+			literal.WriteByte(s[i])
+		}
+	}
+	if literal.Len() > 0 {
+		t = append(t, templatePart{literal: literal.String()})
+	}
+	return t, nil
+}
+
+// hasPlaceholders reports whether the template depends on the number it replaces
+func (t template) hasPlaceholders() bool {
+	for _, part := range t {
+		if part.number != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (t template) expand(i int) string {
+	switch {
+	case len(t) == 0:
+		return ""
+	case len(t) == 1 && t[0].number == nil:
+		return t[0].literal
+	}
+	var b strings.Builder
+	for _, part := range t {
+		if part.number != nil {
+			b.WriteString(part.number.format(i))
+		} else {
+			b.WriteString(part.literal)
+		}
+	}
+	return b.String()
+}
+
+func (t template) check(lo, hi int) error {
+	for _, part := range t {
+		if part.number != nil {
+			if err := part.number.check(lo, hi); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// maxLength returns an upper bound of the length of the template expanded for values from lo to hi. Literals are
+// counted in the unit, numbers in bytes, which bounds their length in any unit.
+func (t template) maxLength(lo, hi int, unit string) int {
+	length := 0
+	for _, part := range t {
+		if part.number != nil {
+			length += part.number.maxLength(lo, hi)
+		} else {
+			length += stringLength(part.literal, unit)
+		}
+	}
+	return length
+}
+
+// formatter writes the values of a validated request
+type formatter struct {
+	int1, int2 int
+	str1, str2 template
+	number     numberFormat
+}
+
+// newFormatter parses the templates and the number format of the request, and checks them against the limits.
+// Templates are bound by the template length rather than the string length, and when the request uses templates
+// or a number format, the values they may expand to are bound by the expanded length.
+func newFormatter(req types.FizzBuzzRequest, limits types.FizzBuzzLimits) (formatter, error) {
+	if err := checkCharacters(req.Str1, req.Str2); err != nil {
+		return formatter{}, err
+	}
+	number, err := parseNumberFormat(req.NumberFormat, i18n.Locale(req.Locale))
+	if err != nil {
+		return formatter{}, err
+	}
+	f := formatter{int1: req.Int1, int2: req.Int2, number: number}
+	if f.str1, err = parseTemplate(req.Str1, number); err != nil {
+		return formatter{}, err
+	}
+	if f.str2, err = parseTemplate(req.Str2, number); err != nil {
+		return formatter{}, err
+	}
+
+	for _, s := range []struct {
+		raw string
+		t   template
+	}{{req.Str1, f.str1}, {req.Str2, f.str2}} {
+		length := stringLength(s.raw, limits.StringLengthUnit)
+		if s.t.hasPlaceholders() && length > limits.MaxTemplateLength {
+			return formatter{}, ErrTemplateLengthExceeded
+		}
+		if !s.t.hasPlaceholders() && length > limits.MaxStringLength {
+			return formatter{}, ErrStringLengthExceeded
+		}
+	}
+
+	if f.plain() || sequenceLength(req) == 0 {
+		return f, nil
+	}
+	start, end, _ := req.Range()
+	lo, hi := min(start, end), max(start, end)
+	for _, check := range []func(lo, hi int) error{f.number.check, f.str1.check, f.str2.check} {
+		if err := check(lo, hi); err != nil {
+			return formatter{}, err
+		}
+	}
+	unit := limits.StringLengthUnit
+	if max(f.str1.maxLength(lo, hi, unit)+f.str2.maxLength(lo, hi, unit), f.number.maxLength(lo, hi)) > limits.MaxExpandedLength {
+		return formatter{}, ErrExpansionExceeded
+	}
+	return f, nil
+}
+
+// plain reports whether the values are the strings and decimal numbers of the classic sequence
+func (f formatter) plain() bool {
+	return f.number == decimalFormat && !f.str1.hasPlaceholders() && !f.str2.hasPlaceholders()
+}
+
+// value returns the value of i in the sequence. 0 is a multiple of every divisor, and is replaced by str1+str2.
+func (f formatter) value(i int) string {
+	switch {
+	case i%f.int1 == 0 && i%f.int2 == 0:
+		return f.str1.expand(i) + f.str2.expand(i)
+	case i%f.int1 == 0:
+		return f.str1.expand(i)
+	case i%f.int2 == 0:
+		return f.str2.expand(i)
+	default:
+		return f.number.format(i)
+	}
+}
+
+var romanNumerals = []struct {
+	value  int
+	symbol string
+}{
+	{1000, "M"}, {900, "CM"}, {500, "D"}, {400, "CD"}, {100, "C"}, {90, "XC"},
+	{50, "L"}, {40, "XL"}, {10, "X"}, {9, "IX"}, {5, "V"}, {4, "IV"}, {1, "I"},
+}
+
+func romanNumeral(i int) string {
+	var b strings.Builder
+	for _, numeral := range romanNumerals {
+		for ; i >= numeral.value; i -= numeral.value {
+			b.WriteString(numeral.symbol)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,117 @@
+package controllers
+
+import (
+	"fizzbuzz-api/internal/fizzbuzzapi/i18n"
+	"fizzbuzz-api/internal/fizzbuzzapi/types"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NumberFormats(t *testing.T) {
+	tests := []struct {
+		format   string
+		i        int
+		expected string
+	}{
+		{"", 42, "42"},
+		{"decimal", -42, "-42"},
+		{"hex", 255, "ff"},
+		{"hex", -255, "-ff"},
+		{"binary", 5, "101"},
+		{"05", 42, "00042"},
+		{"05", -42, "-0042"},
+		{"02", 12345, "12345"},
+		{"roman", 1994, "MCMXCIV"},
+		{"roman", 3888, "MMMDCCCLXXXVIII"},
+		{"words", 0, "zero"},
+		{"words", 7, "seven"},
+		{"words", 40, "forty"},
+		{"words", 115, "one hundred fifteen"},
+		{"words", -1042, "minus one thousand forty-two"},
+		{"words", 1000000, "one million"},
+		{"words", math.MinInt, "minus nine quintillion two hundred twenty-three quadrillion three hundred seventy-two trillion thirty-six billion eight hundred fifty-four million seven hundred seventy-five thousand eight hundred eight"},
+	}
+	for _, tt := range tests {
+		f, err := parseNumberFormat(tt.format, i18n.English)
+		if assert.NoError(t, err, tt.format) {
+			assert.Equal(t, tt.expected, f.format(tt.i), "%s of %d", tt.format, tt.i)
+			assert.LessOrEqual(t, len(f.format(tt.i)), f.maxLength(tt.i, tt.i), "%s of %d", tt.format, tt.i)
+		}
+	}
+
+	for _, format := range []string{"octal", "0", "05x", "0-5", "Hex"} {
+		_, err := parseNumberFormat(format, i18n.English)
+		assert.ErrorIs(t, err, ErrInvalidNumberFormat, format)
+	}
+}
+
+func Test_ParseTemplate(t *testing.T) {
+	tests := []struct {
+		template string
+		expected string // Expanded for 12
+		err      string
+	}{
+		{"fizz", "fizz", ""},
+		{"fizz#{n}", "fizz#12", ""},
+		{"{n:hex}/{n:roman}/{n}", "c/XII/12", ""},
+		{"{{n}}", "{n}", ""},
+		{"{{{n}}}", "{12}", ""},
+		{"fizz{", "", `invalid template "fizz{": unterminated placeholder at offset 4`},
+		{"fizz}", "", `invalid template "fizz}": unmatched } at offset 4`},
+		{"{i}", "", `invalid template "{i}": unknown placeholder {i}, expected {n} or {n:format} at offset 0`},
+		{"{n:octal}", "", `invalid template "{n:octal}" at offset 0: invalid number format "octal"`},
+	}
+	for _, tt := range tests {
+		template, err := parseTemplate(tt.template, decimalFormat)
+		if tt.err != "" {
+			assert.ErrorIs(t, err, ErrInvalidTemplate, tt.template)
+			assert.ErrorContains(t, err, tt.err)
+			continue
+		}
+		if assert.NoError(t, err, tt.template) {
+			assert.Equal(t, tt.expected, template.expand(12), tt.template)
+		}
+	}
+}
+
+func Test_GenerateFizzBuzz_Templates(t *testing.T) {
+	ctrl := NewFizzBuzzController(types.FizzBuzzLimits{
+		MaxLimit:          100,
+		MaxStringLength:   5,
+		MaxTemplateLength: 12,
+		MaxExpandedLength: 30,
+	}, &mockLogger{})
+	start, end := 3996, 4000
+
+	tests := []struct {
+		name     string
+		req      types.FizzBuzzRequest
+		expected []string
+		err      error
+	}{
+		{"templates", types.FizzBuzzRequest{Int1: 3, Int2: 5, Limit: 5, Str1: "f#{n}", Str2: "{n:bin}"}, nil, ErrInvalidTemplate},
+		{"templates and number format", types.FizzBuzzRequest{Int1: 2, Int2: 3, Limit: 6, Str1: "f#{n}", Str2: "{n:binary}", NumberFormat: "roman"}, []string{"I", "f#II", "11", "f#IV", "V", "f#VI110"}, nil},
+		{"templates are longer than strings", types.FizzBuzzRequest{Int1: 3, Int2: 5, Limit: 3, Str1: "fizz#{n:hex}", Str2: "buzz"}, []string{"1", "2", "fizz#3"}, nil},
+		{"template length", types.FizzBuzzRequest{Int1: 3, Int2: 5, Limit: 3, Str1: "fizz#{n:roman}", Str2: "buzz"}, nil, ErrTemplateLengthExceeded},
+		{"plain strings keep their length", types.FizzBuzzRequest{Int1: 3, Int2: 5, Limit: 3, Str1: "fizzz{{}}", Str2: "buzz"}, nil, ErrStringLengthExceeded},
+		{"expansion", types.FizzBuzzRequest{Int1: 3, Int2: 5, Limit: 100, Str1: "f{n}{n}", Str2: "buzz", NumberFormat: "words"}, nil, ErrExpansionExceeded},
+		{"zero-padded width", types.FizzBuzzRequest{Int1: 3, Int2: 5, Limit: 3, Str1: "fizz", Str2: "buzz", NumberFormat: "01000000"}, nil, ErrExpansionExceeded},
+		{"zero-padded placeholder", types.FizzBuzzRequest{Int1: 3, Int2: 5, Limit: 3, Str1: "{n:0999999}", Str2: "buzz"}, nil, ErrExpansionExceeded},
+		{"roman range", types.FizzBuzzRequest{Int1: 3, Int2: 5, Start: &start, End: &end, Str1: "fizz", Str2: "{n:roman}"}, nil, ErrInvalidNumberFormat},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := ctrl.GenerateFizzBuzz(tt.req)
+			assert.ErrorIs(t, err, tt.err)
+			if tt.err == nil {
+				assert.Equal(t, tt.expected, resp.Result)
+			}
+		})
+	}
+
+	_, err := ctrl.GenerateCompact(types.FizzBuzzRequest{Int1: 3, Int2: 5, Limit: 15, Str1: "fizz", Str2: "buzz", NumberFormat: "hex"})
+	assert.ErrorIs(t, err, ErrCompactUnsupported)
+	assert.Equal(t, ErrCodeInvalidParameter, ErrorCode(err))
+}
@@ -0,0 +1,212 @@
+package controllers
+
+import (
+	"fizzbuzz-api/internal/fizzbuzzapi/types"
+	"iter"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Reasons of types.FizzBuzzInferConflict
+const (
+	ConflictUnexpectedNumber = "unexpected_number" // The position holds its number, though rules divide it
+	ConflictUnexpectedValue  = "unexpected_value"  // The value does not start with the strings of the rules dividing the position
+)
+
+// Number of candidates listed by types.FizzBuzzInferResponse. Only the splits of str1str2 between str1 and str2,
+// when int1 equals int2, give more.
+const maxInferCandidates = 100
+
+// Infer deduces the requests producing a sequence of values from 1 to its length, numbers being written in decimal.
+// Divisors dividing no position cannot be told apart, they are listed as unbounded with the value limit + 1.
+// When no request produces the sequence, Infer looks for a rule set with more than two divisors, their strings
+// being concatenated in ascending divisor order. The sequence is bound by the max limit.
+func (ctrl *FizzBuzzController) Infer(values iter.Seq[string]) (types.FizzBuzzInferResponse, error) {
+	limits := ctrl.Limits()
+	var seq []string
+	for v := range values {
+		if len(seq) == limits.MaxLimit {
+			return types.FizzBuzzInferResponse{}, ErrLimitExceeded
+		}
+		seq = append(seq, v)
+	}
+	if len(seq) == 0 {
+		return types.FizzBuzzInferResponse{}, ErrEmptySequence
+	}
+
+	start := time.Now()
+	resp := types.FizzBuzzInferResponse{Limit: len(seq), Candidates: inferRequests(seq)}
+	if len(resp.Candidates) > maxInferCandidates {
+		resp.Candidates, resp.Truncated = resp.Candidates[:maxInferCandidates], true
+	}
+	if len(resp.Candidates) == 0 {
+		resp.Rules, resp.Conflict = inferRules(seq)
+	}
+	resp.Possible = resp.Conflict == nil
+	resp.Ambiguous = len(resp.Candidates) > 1 || (len(resp.Candidates) == 1 && len(resp.Candidates[0].Unbounded) > 0)
+	duration := time.Since(start)
+	resp.Duration = duration.Milliseconds()
+	ctrl.log.Info("fizzBuzz inferred", "length", resp.Limit, "candidates", len(resp.Candidates), "rules", len(resp.Rules), "duration_ms", duration.Milliseconds())
+
+	return resp, nil
+}
+
+// inferRequests returns the requests producing seq. The smallest divisor is the first replaced position p, and
+// the other one the first replaced position that is not a multiple of p holding the value of p: below it, a
+// divisor would be a multiple of p holding the value of p, which cannot hold str1str2. When there is none, the
+// other divisor divides no position, or equals p.
+func inferRequests(seq []string) []types.FizzBuzzInferCandidate {
+	n := len(seq)
+	replaced := func(i int) bool { return seq[i-1] != strconv.Itoa(i) }
+
+	p := 0
+	for i := 1; i <= n && p == 0; i++ {
+		if replaced(i) {
+			p = i
+		}
+	}
+	if p == 0 {
+		return []types.FizzBuzzInferCandidate{{Int1: n + 1, Int2: n + 1, Limit: n, Unbounded: []string{"int1", "int2"}}}
+	}
+	q := 0
+	for i := p + 1; i <= n && q == 0; i++ {
+		if replaced(i) && (i%p != 0 || seq[i-1] != seq[p-1]) {
+			q = i
+		}
+	}
+
+	divisors := [][2]int{{p, q}, {q, p}}
+	if q == 0 {
+		divisors = [][2]int{{p, n + 1}, {n + 1, p}, {p, p}} // n + 1 divides no position
+	}
+	candidates := []types.FizzBuzzInferCandidate{}
+	for _, d := range divisors {
+		candidates = append(candidates, inferStrings(seq, d[0], d[1])...)
+	}
+	return candidates
+}
+
+// inferStrings returns the requests with divisors int1 and int2 producing seq, their strings being read from
+// the first positions holding them
+func inferStrings(seq []string, int1, int2 int) []types.FizzBuzzInferCandidate {
+	n := len(seq)
+	var str1, str2, both *string
+	for i := 1; i <= n; i++ {
+		switch {
+		case i%int1 == 0 && i%int2 == 0 && both == nil:
+			both = &seq[i-1]
+		case i%int1 == 0 && i%int2 != 0 && str1 == nil:
+			str1 = &seq[i-1]
+		case i%int2 == 0 && i%int1 != 0 && str2 == nil:
+			str2 = &seq[i-1]
+		}
+	}
+	if (str1 != nil && *str1 == "") || (str2 != nil && *str2 == "") {
+		return nil
+	}
+
+	var splits [][2]string
+	switch {
+	case both == nil:
+		splits = [][2]string{{deref(str1), deref(str2)}}
+	case str1 != nil && str2 != nil:
+		if *both == *str1+*str2 {
+			splits = [][2]string{{*str1, *str2}}
+		}
+	case str1 != nil:
+		if rest, ok := strings.CutPrefix(*both, *str1); ok && rest != "" {
+			splits = [][2]string{{*str1, rest}}
+		}
+	case str2 != nil:
+		if rest, ok := strings.CutSuffix(*both, *str2); ok && rest != "" {
+			splits = [][2]string{{rest, *str2}}
+		}
+	default:
+		// Equal divisors only write str1str2, which any split produces
+		if !produces(seq, int1, int2, *both, "") {
+			return nil
+		}
+		for k := range *both {
+			if k > 0 && len(splits) <= maxInferCandidates {
+				splits = append(splits, [2]string{(*both)[:k], (*both)[k:]})
+			}
+		}
+		return candidates(n, int1, int2, splits)
+	}
+
+	if len(splits) == 0 || !produces(seq, int1, int2, splits[0][0], splits[0][1]) {
+		return nil
+	}
+	return candidates(n, int1, int2, splits)
+}
+
+func candidates(n, int1, int2 int, splits [][2]string) []types.FizzBuzzInferCandidate {
+	var unbounded []string
+	if int1 > n {
+		unbounded = append(unbounded, "int1")
+	}
+	if int2 > n {
+		unbounded = append(unbounded, "int2")
+	}
+	result := make([]types.FizzBuzzInferCandidate, len(splits))
+	for k, s := range splits {
+		result[k] = types.FizzBuzzInferCandidate{Int1: int1, Int2: int2, Str1: s[0], Str2: s[1], Limit: n, Unbounded: unbounded}
+	}
+	return result
+}
+
+// produces reports whether the request with these divisors and strings generates seq
+func produces(seq []string, int1, int2 int, str1, str2 string) bool {
+	for i := 1; i <= len(seq); i++ {
+		if valueAt(int1, int2, str1, str2, i) != seq[i-1] {
+			return false
+		}
+	}
+	return true
+}
+
+// inferRules returns the smallest rule set producing seq, its strings being concatenated in ascending divisor
+// order, or the first position none explains. Each replaced position no rule found so far divides is a new
+// divisor, as a smaller one would have been found at its own position, and the rules dividing a position
+// write the start of its value, the new rule writing the rest.
+func inferRules(seq []string) ([]types.FizzBuzzRule, *types.FizzBuzzInferConflict) {
+	n := len(seq)
+	rules := []types.FizzBuzzRule{}
+	dividing := make([][]int, n+1) // Rules dividing each position, in ascending divisor order
+	for i := 1; i <= n; i++ {
+		value, number := seq[i-1], strconv.Itoa(i)
+		var expected strings.Builder
+		for _, r := range dividing[i] {
+			expected.WriteString(rules[r].Str)
+		}
+
+		var str string
+		switch prefix := expected.String(); {
+		case prefix != "" && value == prefix, prefix == "" && value == number:
+			continue
+		case value == number:
+			return nil, &types.FizzBuzzInferConflict{Index: i, Value: value, Expected: prefix, Reason: ConflictUnexpectedNumber}
+		case strings.HasPrefix(value, prefix) && len(value) > len(prefix):
+			str = value[len(prefix):]
+		default:
+			if prefix == "" {
+				prefix = number
+			}
+			return nil, &types.FizzBuzzInferConflict{Index: i, Value: value, Expected: prefix, Reason: ConflictUnexpectedValue}
+		}
+
+		rules = append(rules, types.FizzBuzzRule{Divisor: i, Str: str})
+		for m := i; m <= n; m += i {
+			dividing[m] = append(dividing[m], len(rules)-1)
+		}
+	}
+	return rules, nil
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,124 @@
+package controllers
+
+import (
+	"cmp"
+	"fizzbuzz-api/internal/fizzbuzzapi/types"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Infer(t *testing.T) {
+	ctrl := NewFizzBuzzController(types.FizzBuzzLimits{
+		MaxLimit:        100,
+		MaxStringLength: 100,
+	}, &mockLogger{})
+
+	tests := []struct {
+		name       string
+		seq        string
+		ambiguous  bool
+		candidates []types.FizzBuzzInferCandidate
+	}{
+		{"fizzbuzz", "1 2 Fizz 4 Buzz Fizz 7 8 Fizz Buzz 11 Fizz 13 14 FizzBuzz", false,
+			[]types.FizzBuzzInferCandidate{{Int1: 3, Int2: 5, Str1: "Fizz", Str2: "Buzz", Limit: 15}}},
+		{"divisors in any order", "1 2 Fizz 4 Buzz Fizz 7 8 Fizz Buzz 11 Fizz 13 14 BuzzFizz", false,
+			[]types.FizzBuzzInferCandidate{{Int1: 5, Int2: 3, Str1: "Buzz", Str2: "Fizz", Limit: 15}}},
+		{"str2 never written alone", "1 Buzz 3 BuzzFizz", false,
+			[]types.FizzBuzzInferCandidate{{Int1: 2, Int2: 4, Str1: "Buzz", Str2: "Fizz", Limit: 4}}},
+		{"limit below int2", "1 2 Fizz 4", true, []types.FizzBuzzInferCandidate{
+			{Int1: 3, Int2: 5, Str1: "Fizz", Limit: 4, Unbounded: []string{"int2"}},
+			{Int1: 5, Int2: 3, Str2: "Fizz", Limit: 4, Unbounded: []string{"int1"}},
+			{Int1: 3, Int2: 3, Str1: "F", Str2: "izz", Limit: 4},
+			{Int1: 3, Int2: 3, Str1: "Fi", Str2: "zz", Limit: 4},
+			{Int1: 3, Int2: 3, Str1: "Fiz", Str2: "z", Limit: 4},
+		}},
+		{"splits at characters", "1 é😀", true, []types.FizzBuzzInferCandidate{
+			{Int1: 2, Int2: 3, Str1: "é😀", Limit: 2, Unbounded: []string{"int2"}},
+			{Int1: 3, Int2: 2, Str2: "é😀", Limit: 2, Unbounded: []string{"int1"}},
+			{Int1: 2, Int2: 2, Str1: "é", Str2: "😀", Limit: 2},
+		}},
+		{"numbers only", "1 2 3", true,
+			[]types.FizzBuzzInferCandidate{{Int1: 4, Int2: 4, Limit: 3, Unbounded: []string{"int1", "int2"}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			resp, err := ctrl.Infer(slices.Values(strings.Fields(tt.seq)))
+			assert.NoError(err)
+			assert.True(resp.Possible)
+			assert.Equal(tt.ambiguous, resp.Ambiguous)
+			assert.Equal(tt.candidates, resp.Candidates)
+			assert.Nil(resp.Rules)
+			assert.Nil(resp.Conflict)
+
+			// Every candidate produces the sequence, strings of unbounded divisors being never written
+			for _, c := range resp.Candidates {
+				req := types.FizzBuzzRequest{Int1: c.Int1, Int2: c.Int2, Limit: c.Limit, Str1: c.Str1, Str2: c.Str2}
+				req.Str1 = cmp.Or(req.Str1, "unused")
+				req.Str2 = cmp.Or(req.Str2, "unused")
+				full, err := ctrl.GenerateFizzBuzz(req)
+				assert.NoError(err)
+				assert.Equal(strings.Fields(tt.seq), full.Result, "%+v", c)
+			}
+		})
+	}
+}
+
+func Test_Infer_Rules(t *testing.T) {
+	ctrl := NewFizzBuzzController(types.FizzBuzzLimits{
+		MaxLimit:        100,
+		MaxStringLength: 100,
+	}, &mockLogger{})
+
+	tests := []struct {
+		name     string
+		seq      string
+		rules    []types.FizzBuzzRule
+		conflict *types.FizzBuzzInferConflict
+	}{
+		{"three rules", "1 2 Fizz 4 Buzz Fizz Bazz 8 Fizz Buzz 11 Fizz 13 Bazz FizzBuzz", []types.FizzBuzzRule{{Divisor: 3, Str: "Fizz"}, {Divisor: 5, Str: "Buzz"}, {Divisor: 7, Str: "Bazz"}}, nil},
+		{"number replaced", "1 2 Fizz 4 Buzz 6", nil, &types.FizzBuzzInferConflict{Index: 6, Value: "6", Expected: "Fizz", Reason: ConflictUnexpectedNumber}},
+		{"value not extending its rules", "1 2 Fizz 4 5 Buzz", nil, &types.FizzBuzzInferConflict{Index: 6, Value: "Buzz", Expected: "Fizz", Reason: ConflictUnexpectedValue}},
+		{"rule of 1", "Fizz Buzz", nil, &types.FizzBuzzInferConflict{Index: 2, Value: "Buzz", Expected: "Fizz", Reason: ConflictUnexpectedValue}},
+		{"empty value", "1 _", nil, &types.FizzBuzzInferConflict{Index: 2, Value: "", Expected: "2", Reason: ConflictUnexpectedValue}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			seq := strings.Fields(strings.ReplaceAll(tt.seq, "_", " _ "))
+			for i, v := range seq {
+				if v == "_" {
+					seq[i] = ""
+				}
+			}
+			resp, err := ctrl.Infer(slices.Values(seq))
+			assert.NoError(err)
+			assert.Empty(resp.Candidates)
+			assert.Equal(tt.conflict == nil, resp.Possible)
+			assert.Equal(tt.rules, resp.Rules)
+			assert.Equal(tt.conflict, resp.Conflict)
+		})
+	}
+}
+
+func Test_Infer_Bounds(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := NewFizzBuzzController(types.FizzBuzzLimits{
+		MaxLimit:        300,
+		MaxStringLength: 300,
+	}, &mockLogger{})
+
+	_, err := ctrl.Infer(slices.Values([]string{}))
+	assert.Equal(ErrEmptySequence, err)
+	_, err = ctrl.Infer(slices.Values(make([]string, 301)))
+	assert.Equal(ErrLimitExceeded, err)
+
+	// Equal divisors split str1str2 anywhere, listing the first candidates only
+	resp, err := ctrl.Infer(slices.Values([]string{strings.Repeat("z", 200)}))
+	assert.NoError(err)
+	assert.True(resp.Truncated)
+	assert.Len(resp.Candidates, maxInferCandidates)
+}
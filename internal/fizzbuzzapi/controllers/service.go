@@ -4,7 +4,9 @@ import (
 	"errors"
 	"fizzbuzz-api/internal/fizzbuzzapi/logger"
 	"fizzbuzz-api/internal/fizzbuzzapi/types"
+	"iter"
 	"strconv"
+	"sync/atomic"
 	"time"
 )
 
@@ -14,55 +16,256 @@ type FizzBuzzConfig struct {
 }
 
 type FizzBuzzController struct {
-	types.FizzBuzzLimits
-	log logger.Logger
+	limits atomic.Pointer[types.FizzBuzzLimits] // Swapped on config reload, each generation uses a single snapshot
+	log    logger.Logger
 }
 
 var (
 	ErrLimitExceeded        = errors.New("limit exceeds maximum allowed")
 	ErrStringLengthExceeded = errors.New("string length exceeds maximum allowed")
 	ErrNegativeParameter    = errors.New("limit, int1, and int2 must be strictly positive integers")
+	ErrInvalidRange         = errors.New("a range needs an end and no limit, and a non-zero step going from start towards end")
+	ErrUnsafeCharacter      = errors.New("str1 and str2 must not hold control, bidi or zero-width characters")
+	ErrEmptySequence        = errors.New("the sequence must hold at least one value")
+	ErrTooManyDigits        = types.ErrTooManyDigits // Positions beyond int64 are parsed as types.BigInt
+
+	ErrInvalidNumberFormat    = errors.New("invalid number format")
+	ErrInvalidTemplate        = errors.New("invalid template")
+	ErrTemplateLengthExceeded = errors.New("template length exceeds maximum allowed")
+	ErrExpansionExceeded      = errors.New("expanded string length exceeds maximum allowed")
+	ErrCompactUnsupported     = errors.New("the compact format does not support templates nor number formats")
+	ErrPositionalTemplate     = errors.New("positional queries do not support templates")
+)
+
+// Error codes identifying request failures in stats
+const (
+	ErrCodeInvalidRequest       = "invalid_request"
+	ErrCodeInvalidParameter     = "invalid_parameter"
+	ErrCodeLimitExceeded        = "limit_exceeded"
+	ErrCodeStringLengthExceeded = "string_length_exceeded"
+	ErrCodeInternal             = "internal_error"
 )
 
+// ErrorCode maps an error returned by the controller to its error code
+func ErrorCode(err error) string {
+	switch {
+	case errors.Is(err, ErrNegativeParameter), errors.Is(err, ErrInvalidRange), errors.Is(err, ErrUnsafeCharacter),
+		errors.Is(err, ErrInvalidNumberFormat), errors.Is(err, ErrInvalidTemplate), errors.Is(err, ErrCompactUnsupported),
+		errors.Is(err, ErrPositionalTemplate), errors.Is(err, ErrEmptySequence), errors.Is(err, ErrTooManyDigits):
+		return ErrCodeInvalidParameter
+	case errors.Is(err, ErrLimitExceeded):
+		return ErrCodeLimitExceeded
+	case errors.Is(err, ErrStringLengthExceeded), errors.Is(err, ErrTemplateLengthExceeded), errors.Is(err, ErrExpansionExceeded):
+		return ErrCodeStringLengthExceeded
+	default:
+		return ErrCodeInternal
+	}
+}
+
 func NewFizzBuzzController(limits types.FizzBuzzLimits, log logger.Logger) *FizzBuzzController {
-	return &FizzBuzzController{
-		FizzBuzzLimits: limits,
-		log:            log,
+	ctrl := &FizzBuzzController{
+		log: log,
 	}
+	ctrl.SetLimits(limits)
+	return ctrl
+}
+
+// SetLimits atomically replaces the limits, requests being processed keep the limits they started with
+func (ctrl *FizzBuzzController) SetLimits(limits types.FizzBuzzLimits) {
+	ctrl.limits.Store(&limits)
+}
+
+func (ctrl *FizzBuzzController) Limits() types.FizzBuzzLimits {
+	return *ctrl.limits.Load()
 }
 
 func (ctrl *FizzBuzzController) GenerateFizzBuzz(req types.FizzBuzzRequest) (types.FizzBuzzResponse, error) {
-	if req.Limit < 0 || req.Int1 <= 0 || req.Int2 <= 0 {
-		return types.FizzBuzzResponse{}, ErrNegativeParameter
+	f, err := ctrl.validate(req)
+	if err != nil {
+		return types.FizzBuzzResponse{}, err
 	}
 
-	if req.Limit > ctrl.MaxLimit {
-		return types.FizzBuzzResponse{}, ErrLimitExceeded
+	start := time.Now()
+	var result = make([]string, 0, sequenceLength(req))
+	for i := range positions(req) {
+		result = append(result, f.value(i))
 	}
+	duration := time.Since(start)
+	ctrl.log.Info("fizzBuzz generated", "length", len(result), "duration_ms", duration.Milliseconds())
 
-	if len(req.Str1) > ctrl.MaxStringLength || len(req.Str2) > ctrl.MaxStringLength {
-		return types.FizzBuzzResponse{}, ErrStringLengthExceeded
+	return types.FizzBuzzResponse{
+		Result:   result,
+		Duration: duration.Milliseconds(),
+	}, nil
+}
+
+// StreamFizzBuzz validates the request like GenerateFizzBuzz, and returns the sequence as an iterator
+// generating values as they are consumed, so that large sequences are never held in memory
+func (ctrl *FizzBuzzController) StreamFizzBuzz(req types.FizzBuzzRequest) (iter.Seq[string], error) {
+	f, err := ctrl.validate(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(yield func(string) bool) {
+		for i := range positions(req) {
+			if !yield(f.value(i)) {
+				return
+			}
+		}
+	}, nil
+}
+
+// GenerateCompact validates the request like GenerateFizzBuzz, and returns the pattern of the sequence,
+// in O(lcm(int1, int2)) rather than O(limit) when the sequence repeats within the limit.
+// Values depending on more than their divisibility, from templates or number formats, cannot be described.
+func (ctrl *FizzBuzzController) GenerateCompact(req types.FizzBuzzRequest) (types.FizzBuzzCompactResponse, error) {
+	f, err := ctrl.validate(req)
+	if err != nil {
+		return types.FizzBuzzCompactResponse{}, err
+	}
+	if !f.plain() {
+		return types.FizzBuzzCompactResponse{}, ErrCompactUnsupported
 	}
 
 	start := time.Now()
-	var result = make([]string, 0, req.Limit)
-	for i := 1; i <= req.Limit; i++ {
+	first, _, step := req.Range()
+	length := sequenceLength(req)
+	period := patternPeriod(req)
+	pattern := make([]byte, period)
+	for k := range pattern {
+		i := first + k*step
 		switch {
 		case i%req.Int1 == 0 && i%req.Int2 == 0:
-			result = append(result, req.Str1+req.Str2)
+			pattern[k] = types.CompactBoth
 		case i%req.Int1 == 0:
-			result = append(result, req.Str1)
+			pattern[k] = types.CompactStr1
 		case i%req.Int2 == 0:
-			result = append(result, req.Str2)
+			pattern[k] = types.CompactStr2
 		default:
-			result = append(result, strconv.Itoa(i))
+			pattern[k] = types.CompactNumber
 		}
 	}
 	duration := time.Since(start)
-	ctrl.log.Info("fizzBuzz generated", "limit", req.Limit, "duration_ms", duration.Milliseconds())
+	ctrl.log.Info("fizzBuzz pattern generated", "length", length, "period", period, "duration_ms", duration.Milliseconds())
 
-	return types.FizzBuzzResponse{
-		Result:   result,
+	return types.FizzBuzzCompactResponse{
+		Limit:    length,
+		Start:    first,
+		Step:     step,
+		Period:   period,
+		Pattern:  string(pattern),
+		Str1:     req.Str1,
+		Str2:     req.Str2,
 		Duration: duration.Milliseconds(),
 	}, nil
 }
+
+// validate checks the request parameters against a single snapshot of the limits, and returns the formatter
+// writing its values. Ranges are bound by their number of values, whatever the values.
+func (ctrl *FizzBuzzController) validate(req types.FizzBuzzRequest) (formatter, error) {
+	if req.Limit < 0 || req.Int1 <= 0 || req.Int2 <= 0 {
+		return formatter{}, ErrNegativeParameter
+	}
+	if req.IsRange() {
+		start, end, step := req.Range()
+		if req.Limit != 0 || req.End == nil || step == 0 || (step > 0 && end < start) || (step < 0 && end > start) {
+			return formatter{}, ErrInvalidRange
+		}
+	}
+
+	limits := ctrl.Limits()
+	if rangeLength(req.Range()) > uint64(limits.MaxLimit) {
+		return formatter{}, ErrLimitExceeded
+	}
+
+	return newFormatter(req, limits)
+}
+
+// validateStrings checks the strings of positional queries, which write values without their position, and
+// returns them with their escaped braces unescaped, as generate writes them. Placeholders are rejected.
+func (ctrl *FizzBuzzController) validateStrings(str1, str2 string) (string, string, error) {
+	if err := checkCharacters(str1, str2); err != nil {
+		return "", "", err
+	}
+	limits := ctrl.Limits()
+	if stringLength(str1, limits.StringLengthUnit) > limits.MaxStringLength || stringLength(str2, limits.StringLengthUnit) > limits.MaxStringLength {
+		return "", "", ErrStringLengthExceeded
+	}
+	t1, err := parseTemplate(str1, decimalFormat)
+	if err != nil {
+		return "", "", err
+	}
+	t2, err := parseTemplate(str2, decimalFormat)
+	if err != nil {
+		return "", "", err
+	}
+	if t1.hasPlaceholders() || t2.hasPlaceholders() {
+		return "", "", ErrPositionalTemplate
+	}
+	return t1.expand(0), t2.expand(0), nil
+}
+
+// rangeLength returns the number of values from start to end by step, 0 when step goes away from end.
+// It is computed on uint64, as the distance between two ints may overflow int.
+func rangeLength(start, end, step int) uint64 {
+	switch {
+	case step > 0 && end >= start:
+		return (uint64(end)-uint64(start))/uint64(step) + 1
+	case step < 0 && end <= start:
+		return (uint64(start)-uint64(end))/-uint64(step) + 1
+	default:
+		return 0
+	}
+}
+
+// sequenceLength returns the number of values of a validated request
+func sequenceLength(req types.FizzBuzzRequest) int {
+	return int(rangeLength(req.Range()))
+}
+
+// positions returns the values of the range of a validated request, from the first to the last.
+// The last value fits in int, so offsets from the start are computed with wrapping arithmetic.
+func positions(req types.FizzBuzzRequest) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		start, _, step := req.Range()
+		for k := range sequenceLength(req) {
+			if !yield(start + k*step) {
+				return
+			}
+		}
+	}
+}
+
+// patternPeriod returns the number of values after which the matched rules of a validated request repeat,
+// at most its length. Multiples of int come back every int / gcd(int, step) values of the sequence.
+func patternPeriod(req types.FizzBuzzRequest) int {
+	_, _, step := req.Range()
+	period := sequenceLength(req)
+	if l, ok := lcm(req.Int1/absGCD(req.Int1, step), req.Int2/absGCD(req.Int2, step)); ok && l < period {
+		period = l
+	}
+	return period
+}
+
+// absGCD returns the greatest common divisor of a positive integer and any integer
+func absGCD(a, b int) int {
+	g := gcd(a, b)
+	if g < 0 {
+		return -g
+	}
+	return g
+}
+
+func valueAt[T int | int64](int1, int2 T, str1, str2 string, i T) string {
+	switch {
+	case i%int1 == 0 && i%int2 == 0:
+		return str1 + str2
+	case i%int1 == 0:
+		return str1
+	case i%int2 == 0:
+		return str2
+	default:
+		return strconv.FormatInt(int64(i), 10)
+	}
+}
@@ -1,7 +1,15 @@
 package controllers
 
 import (
+	"fizzbuzz-api/internal/fizzbuzzapi/i18n"
 	"fizzbuzz-api/internal/fizzbuzzapi/types"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"math"
+	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -133,3 +141,215 @@ func Test_GenerateFizzBuzz_ZeroLimit(t *testing.T) {
 	assert.NoError(err, "Error should be nil for zero limit")
 	assert.Equal(0, len(resp.Result), "Result should be empty for zero limit")
 }
+
+func Test_SetLimits(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := NewFizzBuzzController(types.FizzBuzzLimits{
+		MaxLimit:        10,
+		MaxStringLength: 100,
+	}, &mockLogger{})
+	req := types.FizzBuzzRequest{Int1: 3, Int2: 5, Limit: 15, Str1: "Fizz", Str2: "Buzz"}
+
+	_, err := ctrl.GenerateFizzBuzz(req)
+	assert.Equal(ErrLimitExceeded, err)
+
+	ctrl.SetLimits(types.FizzBuzzLimits{MaxLimit: 20, MaxStringLength: 100})
+	resp, err := ctrl.GenerateFizzBuzz(req)
+	assert.NoError(err)
+	assert.Len(resp.Result, 15)
+	assert.Equal(20, ctrl.Limits().MaxLimit)
+}
+
+func Test_StreamFizzBuzz(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := NewFizzBuzzController(types.FizzBuzzLimits{
+		MaxLimit:        100,
+		MaxStringLength: 100,
+	}, &mockLogger{})
+	req := types.FizzBuzzRequest{Int1: 3, Int2: 5, Limit: 15, Str1: "Fizz", Str2: "Buzz"}
+
+	seq, err := ctrl.StreamFizzBuzz(req)
+	assert.NoError(err)
+	resp, _ := ctrl.GenerateFizzBuzz(req)
+	assert.Equal(resp.Result, slices.Collect(seq), "Streamed values should match the generated sequence")
+
+	var first []string
+	for v := range seq {
+		first = append(first, v)
+		if len(first) == 3 {
+			break
+		}
+	}
+	assert.Equal([]string{"1", "2", "Fizz"}, first)
+
+	req.Limit = 1000
+	_, err = ctrl.StreamFizzBuzz(req)
+	assert.Equal(ErrLimitExceeded, err, "Limits should be checked before streaming")
+}
+
+func Test_GenerateCompact(t *testing.T) {
+	ctrl := NewFizzBuzzController(types.FizzBuzzLimits{
+		MaxLimit:        1000,
+		MaxStringLength: 100,
+	}, &mockLogger{})
+
+	// Expanding the pattern must give back the generated sequence, whatever the divisors and the limit
+	for _, int1 := range []int{1, 2, 3, 4, 6, 7, 10, 999, math.MaxInt} {
+		for _, int2 := range []int{1, 3, 5, 6, 12, 1000, math.MaxInt - 1} {
+			for _, limit := range []int{0, 1, 14, 15, 16, 100, 1000} {
+				req := types.FizzBuzzRequest{Int1: int1, Int2: int2, Limit: limit, Str1: "Fizz", Str2: "Buzz"}
+				full, err := ctrl.GenerateFizzBuzz(req)
+				assert.NoError(t, err)
+				compact, err := ctrl.GenerateCompact(req)
+				assert.NoError(t, err)
+
+				assert.Equal(t, full.Result, slices.AppendSeq([]string{}, compact.Expand()), "%+v", req)
+				assert.Len(t, compact.Pattern, compact.Period)
+			}
+		}
+	}
+}
+
+func Test_GenerateCompact_Period(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := NewFizzBuzzController(types.FizzBuzzLimits{
+		MaxLimit:        100000,
+		MaxStringLength: 100,
+	}, &mockLogger{})
+
+	compact, err := ctrl.GenerateCompact(types.FizzBuzzRequest{Int1: 3, Int2: 5, Limit: 100000, Str1: "Fizz", Str2: "Buzz"})
+	assert.NoError(err)
+	assert.Equal(15, compact.Period)
+	assert.Equal("..1.21..12.1..3", compact.Pattern)
+	assert.Equal("FizzBuzz", compact.At(99990))
+	assert.Equal("99991", compact.At(99991))
+	assert.Equal("", compact.At(0))
+	assert.Equal("", compact.At(100001))
+
+	// An empty sequence has an empty pattern
+	compact, err = ctrl.GenerateCompact(types.FizzBuzzRequest{Int1: 3, Int2: 5, Limit: 0, Str1: "Fizz", Str2: "Buzz"})
+	assert.NoError(err)
+	assert.Equal(0, compact.Period)
+	assert.Equal("", compact.At(1))
+
+	// The sequence does not repeat within the limit
+	compact, _ = ctrl.GenerateCompact(types.FizzBuzzRequest{Int1: 30, Int2: 50, Limit: 100, Str1: "Fizz", Str2: "Buzz"})
+	assert.Equal(100, compact.Period)
+
+	_, err = ctrl.GenerateCompact(types.FizzBuzzRequest{Int1: 3, Int2: 5, Limit: 100001, Str1: "Fizz", Str2: "Buzz"})
+	assert.Equal(ErrLimitExceeded, err)
+}
+
+func Test_GenerateFizzBuzz_Range(t *testing.T) {
+	ctrl := NewFizzBuzzController(types.FizzBuzzLimits{
+		MaxLimit:        5,
+		MaxStringLength: 100,
+	}, &mockLogger{})
+	ptr := func(i int) *int { return &i }
+
+	tests := []struct {
+		name             string
+		start, end, step *int
+		expected         []string
+		err              error
+	}{
+		{"negative to positive", ptr(-2), ptr(2), nil, []string{"-2", "-1", "FizzBuzz", "1", "2"}, nil},
+		{"descending by default", ptr(5), ptr(1), nil, []string{"Buzz", "4", "Fizz", "2", "1"}, nil},
+		{"start defaults to 1", nil, ptr(3), nil, []string{"1", "2", "Fizz"}, nil},
+		{"step skips values", ptr(-30), ptr(0), ptr(7), []string{"FizzBuzz", "-23", "-16", "Fizz", "-2"}, nil},
+		{"end not reached by step", ptr(0), ptr(10), ptr(4), []string{"FizzBuzz", "4", "8"}, nil},
+		{"single value", ptr(9), ptr(9), ptr(-1), []string{"Fizz"}, nil},
+		{"values are not bound", ptr(math.MaxInt - 1), ptr(math.MaxInt), nil, []string{"Fizz", "9223372036854775807"}, nil},
+		{"span overflowing int", ptr(math.MinInt), ptr(math.MaxInt), ptr(math.MaxInt), []string{"-9223372036854775808", "-1", "Fizz"}, nil},
+		{"count is bound", ptr(1), ptr(6), nil, nil, ErrLimitExceeded},
+		{"zero step", ptr(1), ptr(3), ptr(0), nil, ErrInvalidRange},
+		{"step away from end", ptr(1), ptr(3), ptr(-1), nil, ErrInvalidRange},
+		{"missing end", ptr(1), nil, nil, nil, ErrInvalidRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := types.FizzBuzzRequest{Int1: 3, Int2: 5, Start: tt.start, End: tt.end, Step: tt.step, Str1: "Fizz", Str2: "Buzz"}
+			resp, err := ctrl.GenerateFizzBuzz(req)
+			assert.Equal(t, tt.err, err)
+			if tt.err != nil {
+				return
+			}
+			assert.Equal(t, tt.expected, resp.Result)
+
+			seq, _ := ctrl.StreamFizzBuzz(req)
+			assert.Equal(t, tt.expected, slices.Collect(seq))
+			compact, err := ctrl.GenerateCompact(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, slices.Collect(compact.Expand()))
+		})
+	}
+
+	// A range cannot be combined with a limit
+	_, err := ctrl.GenerateFizzBuzz(types.FizzBuzzRequest{Int1: 3, Int2: 5, Limit: 3, End: ptr(3), Str1: "Fizz", Str2: "Buzz"})
+	assert.Equal(t, ErrInvalidRange, err)
+}
+
+func Test_GenerateCompact_RangePeriod(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := NewFizzBuzzController(types.FizzBuzzLimits{
+		MaxLimit:        1000,
+		MaxStringLength: 100,
+	}, &mockLogger{})
+	start, end, step := -501, 501, 3
+
+	// Every value is a multiple of 3, multiples of 5 come back every 5 values
+	compact, err := ctrl.GenerateCompact(types.FizzBuzzRequest{Int1: 3, Int2: 5, Start: &start, End: &end, Step: &step, Str1: "Fizz", Str2: "Buzz"})
+	assert.NoError(err)
+	assert.Equal(335, compact.Limit)
+	assert.Equal(5, compact.Period)
+	assert.Equal("11311", compact.Pattern)
+	assert.Equal("FizzBuzz", compact.At(3))
+	assert.Equal("Fizz", compact.At(335))
+}
+
+// sentinels lists the exported errors of the package, which reach clients in their language
+var sentinels = map[string]error{
+	"ErrLimitExceeded": ErrLimitExceeded, "ErrStringLengthExceeded": ErrStringLengthExceeded,
+	"ErrNegativeParameter": ErrNegativeParameter, "ErrInvalidRange": ErrInvalidRange, "ErrUnsafeCharacter": ErrUnsafeCharacter,
+	"ErrEmptySequence": ErrEmptySequence, "ErrTooManyDigits": ErrTooManyDigits, "ErrInvalidNumberFormat": ErrInvalidNumberFormat,
+	"ErrInvalidTemplate": ErrInvalidTemplate, "ErrTemplateLengthExceeded": ErrTemplateLengthExceeded,
+	"ErrExpansionExceeded": ErrExpansionExceeded, "ErrCompactUnsupported": ErrCompactUnsupported,
+	"ErrPositionalTemplate": ErrPositionalTemplate,
+}
+
+func Test_ErrorsTranslated(t *testing.T) {
+	// Every exported sentinel declared in the package must be listed, so that new ones get translated
+	names, err := filepath.Glob("*.go")
+	assert.NoError(t, err)
+	for _, name := range names {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(token.NewFileSet(), name, nil, 0)
+		assert.NoError(t, err)
+		for _, decl := range file.Decls {
+			if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.VAR {
+				for _, spec := range gen.Specs {
+					for _, name := range spec.(*ast.ValueSpec).Names {
+						if strings.HasPrefix(name.Name, "Err") {
+							assert.Contains(t, sentinels, name.Name, "%s is not checked for translations", name.Name)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	errs := []error{errRomanRange}
+	for _, err := range sentinels {
+		errs = append(errs, err)
+	}
+	for _, locale := range i18n.Supported {
+		if locale == i18n.English {
+			continue
+		}
+		for _, err := range errs {
+			assert.NotEqual(t, err.Error(), i18n.TranslateError(locale, err), "%s lacks a translation of %q", locale, err)
+		}
+	}
+}
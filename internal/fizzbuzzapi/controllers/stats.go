@@ -1,25 +1,44 @@
 package controllers
 
 import (
+	"context"
 	"encoding/json"
 	"fizzbuzz-api/internal/fizzbuzzapi/logger"
 	"fizzbuzz-api/internal/fizzbuzzapi/types"
+	"fmt"
+	"maps"
+	"slices"
 	"sync"
+	"time"
+
+	"golang.org/x/text/unicode/norm"
 )
 
-type StatsRecord map[string]int
+type statsEntry struct {
+	count    int
+	lastSeen time.Time
+	variants map[string]int // Serialized requests counted under this entry, with their own counts
+}
+
+type StatsRecord map[string]*statsEntry
 
 type FizzBuzzStatsController struct {
+	keying StatsKeying
 	record StatsRecord
+	errors map[string]int // Failed requests by error code
 	log    logger.Logger
+	now    func() time.Time // Overridable clock, used to stamp entries for retention
 
 	sync.Mutex
 }
 
-func NewFizzBuzzStatsController(log logger.Logger) *FizzBuzzStatsController {
+func NewFizzBuzzStatsController(keying StatsKeying, log logger.Logger) *FizzBuzzStatsController {
 	return &FizzBuzzStatsController{
+		keying: keying,
 		record: make(StatsRecord),
+		errors: make(map[string]int),
 		log:    log,
+		now:    time.Now,
 	}
 }
 
@@ -29,36 +48,114 @@ func (ctrl *FizzBuzzStatsController) GetStats() types.FizzBuzzStats {
 
 	highestCount := 0
 	var mostFrequentRequests []string
-	for _, count := range ctrl.record {
-		if count > highestCount {
-			highestCount = count
+	for _, entry := range ctrl.record {
+		if entry.count > highestCount {
+			highestCount = entry.count
 		}
 	}
-	for req, count := range ctrl.record {
-		if count == highestCount {
+	for req, entry := range ctrl.record {
+		if entry.count == highestCount {
 			mostFrequentRequests = append(mostFrequentRequests, req)
 		}
 	}
 
-	return types.FizzBuzzStats{
+	stats := types.FizzBuzzStats{
 		MostFrequentRequests: ctrl.deserializeRequests(mostFrequentRequests),
 		Count:                highestCount,
 	}
+	if ctrl.keying != StatsKeyingExact {
+		stats.Variants = make([][]types.FizzBuzzRequest, len(mostFrequentRequests))
+		for i, req := range mostFrequentRequests {
+			stats.Variants[i] = ctrl.deserializeRequests(slices.Sorted(maps.Keys(ctrl.record[req].variants)))
+		}
+	}
+	return stats
 }
 
 func (ctrl *FizzBuzzStatsController) SaveStat(req types.FizzBuzzRequest) error {
 	ctrl.Lock()
 	defer ctrl.Unlock()
 
-	str, err := ctrl.serializeRequest(req)
+	// Requests differing only by how their characters are encoded, e.g. precomposed or combining accents, are counted together
+	req.Str1, req.Str2 = norm.NFC.String(req.Str1), norm.NFC.String(req.Str2)
+	str, err := ctrl.serializeRequest(ctrl.keying.Canonical(req))
 	if err != nil {
 		return err
 	}
-	ctrl.record[str]++
-	ctrl.log.Info("stat recorded", "request", str, "new_count", ctrl.record[str])
+	variant, err := ctrl.serializeRequest(req)
+	if err != nil {
+		return err
+	}
+	entry, ok := ctrl.record[str]
+	if !ok {
+		entry = &statsEntry{variants: make(map[string]int)}
+		ctrl.record[str] = entry
+	}
+	entry.count++
+	entry.lastSeen = ctrl.now()
+	entry.variants[variant]++
+	ctrl.log.Info("stat recorded", "request", str, "variant", variant, "new_count", entry.count)
 	return nil
 }
 
+// SaveError records a failed request under its error code
+func (ctrl *FizzBuzzStatsController) SaveError(code string) {
+	ctrl.Lock()
+	defer ctrl.Unlock()
+
+	ctrl.errors[code]++
+	ctrl.log.Info("error recorded", "code", code, "new_count", ctrl.errors[code])
+}
+
+// ResetStats removes every entry whose canonical request or one of its variants matches the filter,
+// and returns how many were removed. An empty filter removes everything, error counts included.
+func (ctrl *FizzBuzzStatsController) ResetStats(filter types.FizzBuzzStatsFilter) int {
+	ctrl.Lock()
+	defer ctrl.Unlock()
+
+	filter.Str1, filter.Str2 = normalized(filter.Str1), normalized(filter.Str2)
+	removed := 0
+	for str, entry := range ctrl.record {
+		reqs := ctrl.deserializeRequests(append([]string{str}, slices.Collect(maps.Keys(entry.variants))...))
+		if slices.ContainsFunc(reqs, filter.Matches) {
+			delete(ctrl.record, str)
+			removed++
+		}
+	}
+	if filter.Empty() {
+		clear(ctrl.errors)
+	}
+	ctrl.log.Info("stats reset", "filter", filter, "removed", removed)
+	return removed
+}
+
+// PruneStats removes every entry that has not been seen since before and returns how many were removed.
+func (ctrl *FizzBuzzStatsController) PruneStats(before time.Time) int {
+	ctrl.Lock()
+	defer ctrl.Unlock()
+
+	removed := 0
+	for str, entry := range ctrl.record {
+		if entry.lastSeen.Before(before) {
+			delete(ctrl.record, str)
+			removed++
+		}
+	}
+	if removed > 0 {
+		ctrl.log.Info("stats pruned", "before", before, "removed", removed)
+	}
+	return removed
+}
+
+// normalized returns a copy of s normalized to NFC, like the strings of recorded requests
+func normalized(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	n := norm.NFC.String(*s)
+	return &n
+}
+
 func (ctrl *FizzBuzzStatsController) serializeRequest(req types.FizzBuzzRequest) (string, error) {
 	b, err := json.Marshal(req)
 	if err != nil {
@@ -75,3 +172,22 @@ func (ctrl *FizzBuzzStatsController) deserializeRequests(str []string) []types.F
 	}
 	return reqs
 }
+
+// Interval between two attempts of Ping to lock the stats record
+const pingRetryInterval = 5 * time.Millisecond
+
+// Ping checks that the stats can be accessed, i.e. that the record is not locked beyond the context deadline.
+// It polls with TryLock rather than blocking on Lock, so that no goroutine is left waiting after a timeout.
+func (ctrl *FizzBuzzStatsController) Ping(ctx context.Context) error {
+	ticker := time.NewTicker(pingRetryInterval)
+	defer ticker.Stop()
+	for !ctrl.TryLock() {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return fmt.Errorf("stats record unavailable: %w", ctx.Err())
+		}
+	}
+	ctrl.Unlock()
+	return nil
+}
@@ -0,0 +1,96 @@
+package controllers
+
+import (
+	"cmp"
+	"fizzbuzz-api/internal/fizzbuzzapi/types"
+	"fmt"
+)
+
+// StatsKeying defines which requests are counted together in stats
+type StatsKeying string
+
+const (
+	// Requests are counted together only when all their parameters are equal
+	StatsKeyingExact StatsKeying = "exact"
+	// Requests are counted together regardless of the order of the (int, str) pairs
+	StatsKeyingSymmetric StatsKeying = "symmetric"
+	// Requests are counted together when they produce the exact same sequence
+	StatsKeyingOutputEquivalent StatsKeying = "output-equivalent"
+)
+
+func ParseStatsKeying(s string) (StatsKeying, error) {
+	switch k := StatsKeying(s); k {
+	case StatsKeyingExact, StatsKeyingSymmetric, StatsKeyingOutputEquivalent:
+		return k, nil
+	default:
+		return "", fmt.Errorf("unknown stats keying %q, expected %q, %q or %q", s, StatsKeyingExact, StatsKeyingSymmetric, StatsKeyingOutputEquivalent)
+	}
+}
+
+// Canonical returns the representative request of the group req belongs to
+func (k StatsKeying) Canonical(req types.FizzBuzzRequest) types.FizzBuzzRequest {
+	switch k {
+	case StatsKeyingSymmetric:
+		return sortPairs(req)
+	case StatsKeyingOutputEquivalent:
+		return outputCanonical(req)
+	default:
+		return req
+	}
+}
+
+// sortPairs orders the (int1, str1) and (int2, str2) pairs ascending
+func sortPairs(req types.FizzBuzzRequest) types.FizzBuzzRequest {
+	if cmp.Or(cmp.Compare(req.Int1, req.Int2), cmp.Compare(req.Str1, req.Str2)) > 0 {
+		req.Int1, req.Int2 = req.Int2, req.Int1
+		req.Str1, req.Str2 = req.Str2, req.Str1
+	}
+	return req
+}
+
+// outputCanonical collapses requests generating identical sequences:
+//   - a rule whose divisor is above the limit never applies, its divisor becomes limit+1 and its string is dropped
+//   - two rules with the same divisor only ever output str1+str2, which is moved to str1
+//   - when no number is a multiple of both divisors, the strings are never concatenated and the pairs can be sorted
+//
+// Ranges are only grouped with identical ranges, their default start and step made explicit.
+func outputCanonical(req types.FizzBuzzRequest) types.FizzBuzzRequest {
+	if req.IsRange() {
+		start, end, step := req.Range()
+		req.Start, req.End, req.Step = &start, &end, &step
+		return req
+	}
+	if req.Limit <= 0 {
+		return types.FizzBuzzRequest{}
+	}
+
+	if req.Int1 > req.Limit {
+		req.Int1, req.Str1 = req.Limit+1, ""
+	}
+	if req.Int2 > req.Limit {
+		req.Int2, req.Str2 = req.Limit+1, ""
+	}
+	if req.Int1 == req.Int2 && req.Int1 <= req.Limit {
+		req.Str1, req.Str2 = req.Str1+req.Str2, ""
+	}
+	if l, ok := lcm(req.Int1, req.Int2); !ok || l > req.Limit {
+		req = sortPairs(req)
+	}
+	return req
+}
+
+func gcd[T int | int64](a, b T) T {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// lcm returns the least common multiple of two positive integers, ok is false on overflow
+func lcm[T int | int64](a, b T) (l T, ok bool) {
+	q := a / gcd(a, b)
+	if l = q * b; l/b != q {
+		return 0, false
+	}
+	return l, true
+}
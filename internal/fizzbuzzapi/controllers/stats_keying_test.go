@@ -0,0 +1,123 @@
+package controllers
+
+import (
+	"fizzbuzz-api/internal/fizzbuzzapi/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseStatsKeying(t *testing.T) {
+	assert := assert.New(t)
+	keying, err := ParseStatsKeying("symmetric")
+	assert.NoError(err)
+	assert.Equal(StatsKeyingSymmetric, keying)
+
+	_, err = ParseStatsKeying("fuzzy")
+	assert.Error(err)
+}
+
+func Test_Canonical_Symmetric(t *testing.T) {
+	assert := assert.New(t)
+	req1 := types.FizzBuzzRequest{Int1: 3, Int2: 5, Limit: 15, Str1: "Fizz", Str2: "Buzz"}
+	req2 := types.FizzBuzzRequest{Int1: 5, Int2: 3, Limit: 15, Str1: "Buzz", Str2: "Fizz"}
+
+	assert.Equal(req1, StatsKeyingSymmetric.Canonical(req1))
+	assert.Equal(req1, StatsKeyingSymmetric.Canonical(req2))
+	assert.Equal(req2, StatsKeyingExact.Canonical(req2))
+}
+
+// Each group of requests must generate the same sequence, and share the same canonical form
+func Test_Canonical_OutputEquivalent(t *testing.T) {
+	groups := [][]types.FizzBuzzRequest{
+		// No multiple of 15 below the limit, pairs can be swapped
+		{
+			{Int1: 3, Int2: 5, Limit: 14, Str1: "Fizz", Str2: "Buzz"},
+			{Int1: 5, Int2: 3, Limit: 14, Str1: "Buzz", Str2: "Fizz"},
+		},
+		// Divisors above the limit never apply
+		{
+			{Int1: 3, Int2: 20, Limit: 10, Str1: "Fizz", Str2: "Buzz"},
+			{Int1: 3, Int2: 11, Limit: 10, Str1: "Fizz", Str2: "Bar"},
+			{Int1: 50, Int2: 3, Limit: 10, Str1: "Foo", Str2: "Fizz"},
+		},
+		// Equal divisors only output the concatenation
+		{
+			{Int1: 4, Int2: 4, Limit: 20, Str1: "Fi", Str2: "zz"},
+			{Int1: 4, Int2: 4, Limit: 20, Str1: "F", Str2: "izz"},
+		},
+	}
+
+	ctrl := NewFizzBuzzController(types.FizzBuzzLimits{MaxLimit: 100, MaxStringLength: 100}, &mockLogger{})
+	for _, group := range groups {
+		expected, err := ctrl.GenerateFizzBuzz(group[0])
+		assert.NoError(t, err)
+		for _, req := range group[1:] {
+			resp, err := ctrl.GenerateFizzBuzz(req)
+			assert.NoError(t, err)
+			assert.Equal(t, expected.Result, resp.Result, "request %+v", req)
+			assert.Equal(t, StatsKeyingOutputEquivalent.Canonical(group[0]), StatsKeyingOutputEquivalent.Canonical(req), "request %+v", req)
+		}
+	}
+}
+
+func Test_Canonical_OutputEquivalent_Distinct(t *testing.T) {
+	assert := assert.New(t)
+	// 15 is a multiple of both divisors, so FizzBuzz and BuzzFizz differ
+	req1 := types.FizzBuzzRequest{Int1: 3, Int2: 5, Limit: 15, Str1: "Fizz", Str2: "Buzz"}
+	req2 := types.FizzBuzzRequest{Int1: 5, Int2: 3, Limit: 15, Str1: "Buzz", Str2: "Fizz"}
+	assert.NotEqual(StatsKeyingOutputEquivalent.Canonical(req1), StatsKeyingOutputEquivalent.Canonical(req2))
+}
+
+func Test_SaveStat_Variants(t *testing.T) {
+	assert := assert.New(t)
+	recorder := NewFizzBuzzStatsController(StatsKeyingSymmetric, &mockLogger{})
+	req1 := types.FizzBuzzRequest{Int1: 3, Int2: 5, Limit: 15, Str1: "Fizz", Str2: "Buzz"}
+	req2 := types.FizzBuzzRequest{Int1: 5, Int2: 3, Limit: 15, Str1: "Buzz", Str2: "Fizz"}
+	recorder.SaveStat(req1)
+	recorder.SaveStat(req2)
+	recorder.SaveStat(req2)
+
+	stats := recorder.GetStats()
+	assert.Equal(3, stats.Count)
+	assert.Equal([]types.FizzBuzzRequest{req1}, stats.MostFrequentRequests)
+	assert.Len(stats.Variants, 1)
+	assert.ElementsMatch([]types.FizzBuzzRequest{req1, req2}, stats.Variants[0])
+
+	// Filters also match variants
+	int1 := 5
+	assert.Equal(1, recorder.ResetStats(types.FizzBuzzStatsFilter{Int1: &int1}))
+}
+
+func Test_GetSummary_OutputEquivalent(t *testing.T) {
+	assert := assert.New(t)
+	recorder := NewFizzBuzzStatsController(StatsKeyingOutputEquivalent, &mockLogger{})
+	// Both divisors are above the limit, the canonical request replaces them with limit+1 and drops the strings
+	req1 := types.FizzBuzzRequest{Int1: 20, Int2: 30, Limit: 10, Str1: "Fizz", Str2: "Buzz"}
+	req2 := types.FizzBuzzRequest{Int1: 40, Int2: 50, Limit: 10, Str1: "Foo", Str2: "Bar"}
+	recorder.SaveStat(req1)
+	recorder.SaveStat(req1)
+	recorder.SaveStat(req2)
+
+	summary := recorder.GetSummary()
+	assert.Equal(3, summary.TotalRequests)
+	assert.Equal(2, summary.UniqueRequests)
+	assert.Equal([]types.FizzBuzzDivisorPair{
+		{Int1: 20, Int2: 30, Count: 2},
+		{Int1: 40, Int2: 50, Count: 1},
+	}, summary.TopDivisorPairs)
+	assert.Equal([]types.FizzBuzzStringPair{
+		{Str1: "Fizz", Str2: "Buzz", Count: 2},
+		{Str1: "Foo", Str2: "Bar", Count: 1},
+	}, summary.TopStringPairs)
+}
+
+func Test_LCM_Overflow(t *testing.T) {
+	assert := assert.New(t)
+	l, ok := lcm(4, 6)
+	assert.True(ok)
+	assert.Equal(12, l)
+
+	_, ok = lcm(1<<62, 3)
+	assert.False(ok)
+}
@@ -0,0 +1,81 @@
+package controllers
+
+import (
+	"cmp"
+	"fizzbuzz-api/internal/fizzbuzzapi/types"
+	"maps"
+	"slices"
+)
+
+// Number of entries returned in the top divisor and string pairs of the summary
+const summaryTopPairs = 10
+
+func (ctrl *FizzBuzzStatsController) GetSummary() types.FizzBuzzStatsSummary {
+	ctrl.Lock()
+	defer ctrl.Unlock()
+
+	summary := types.FizzBuzzStatsSummary{
+		ErrorRequests: maps.Clone(ctrl.errors),
+	}
+
+	buckets := map[int]int{} // Bucket upper bound -> count
+	divisorPairs := map[[2]int]int{}
+	stringPairs := map[[2]string]int{}
+	// Variants are the requests actually received, whereas the canonical request of an entry
+	// may have been rewritten by the keying, e.g. a divisor above the limit replaced by limit+1
+	for _, entry := range ctrl.record {
+		for str, count := range entry.variants {
+			req := ctrl.deserializeRequests([]string{str})[0]
+			summary.TotalRequests += count
+			summary.UniqueRequests++
+			buckets[limitBucketMax(sequenceLength(req))] += count // Ranges count as their number of values
+			divisorPairs[[2]int{req.Int1, req.Int2}] += count
+			stringPairs[[2]string{req.Str1, req.Str2}] += count
+		}
+	}
+
+	for _, max := range slices.Sorted(maps.Keys(buckets)) {
+		summary.LimitHistogram = append(summary.LimitHistogram, types.FizzBuzzLimitBucket{
+			Min:   limitBucketMin(max),
+			Max:   max,
+			Count: buckets[max],
+		})
+	}
+
+	for pair, count := range divisorPairs {
+		summary.TopDivisorPairs = append(summary.TopDivisorPairs, types.FizzBuzzDivisorPair{Int1: pair[0], Int2: pair[1], Count: count})
+	}
+	slices.SortFunc(summary.TopDivisorPairs, func(a, b types.FizzBuzzDivisorPair) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Int1, b.Int1), cmp.Compare(a.Int2, b.Int2))
+	})
+	summary.TopDivisorPairs = summary.TopDivisorPairs[:min(len(summary.TopDivisorPairs), summaryTopPairs)]
+
+	for pair, count := range stringPairs {
+		summary.TopStringPairs = append(summary.TopStringPairs, types.FizzBuzzStringPair{Str1: pair[0], Str2: pair[1], Count: count})
+	}
+	slices.SortFunc(summary.TopStringPairs, func(a, b types.FizzBuzzStringPair) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Str1, b.Str1), cmp.Compare(a.Str2, b.Str2))
+	})
+	summary.TopStringPairs = summary.TopStringPairs[:min(len(summary.TopStringPairs), summaryTopPairs)]
+
+	return summary
+}
+
+// limitBucketMax returns the upper bound of the power of ten bucket containing limit: 0, 10, 100, 1000...
+func limitBucketMax(limit int) int {
+	if limit <= 0 {
+		return 0
+	}
+	max := 10
+	for limit > max {
+		max *= 10
+	}
+	return max
+}
+
+func limitBucketMin(max int) int {
+	if max <= 10 {
+		return min(max, 1)
+	}
+	return max/10 + 1
+}
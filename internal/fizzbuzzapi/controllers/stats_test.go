@@ -1,15 +1,17 @@
 package controllers
 
 import (
+	"context"
 	"fizzbuzz-api/internal/fizzbuzzapi/types"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
 
 func Test_GetStats(t *testing.T) {
 	assert := assert.New(t)
-	recorder := NewFizzBuzzStatsController(&mockLogger{})
+	recorder := NewFizzBuzzStatsController(StatsKeyingExact, &mockLogger{})
 
 	req1 := types.FizzBuzzRequest{Int1: 3, Int2: 5, Limit: 15, Str1: "Fizz", Str2: "Buzz"}
 	req2 := types.FizzBuzzRequest{Int1: 2, Int2: 4, Limit: 10, Str1: "Foo", Str2: "Bar"}
@@ -24,7 +26,7 @@ func Test_GetStats(t *testing.T) {
 
 func Test_GetStats_Empty(t *testing.T) {
 	assert := assert.New(t)
-	recorder := NewFizzBuzzStatsController(&mockLogger{})
+	recorder := NewFizzBuzzStatsController(StatsKeyingExact, &mockLogger{})
 	stats := recorder.GetStats()
 	assert.Equal(0, stats.Count)
 	assert.Empty(stats.MostFrequentRequests)
@@ -32,7 +34,7 @@ func Test_GetStats_Empty(t *testing.T) {
 
 func Test_SerializeDeserializeRequest(t *testing.T) {
 	assert := assert.New(t)
-	recorder := NewFizzBuzzStatsController(&mockLogger{})
+	recorder := NewFizzBuzzStatsController(StatsKeyingExact, &mockLogger{})
 	req := types.FizzBuzzRequest{Int1: 3, Int2: 5, Limit: 15, Str1: "Fizz", Str2: "Buzz"}
 
 	serialized, err := recorder.serializeRequest(req)
@@ -45,7 +47,7 @@ func Test_SerializeDeserializeRequest(t *testing.T) {
 
 func Test_StatsExAequo(t *testing.T) {
 	assert := assert.New(t)
-	recorder := NewFizzBuzzStatsController(&mockLogger{})
+	recorder := NewFizzBuzzStatsController(StatsKeyingExact, &mockLogger{})
 	req1 := types.FizzBuzzRequest{Int1: 3, Int2: 5, Limit: 15, Str1: "Fizz", Str2: "Buzz"}
 	req2 := types.FizzBuzzRequest{Int1: 2, Int2: 4, Limit: 10, Str1: "Foo", Str2: "Bar"}
 	req3 := types.FizzBuzzRequest{Int1: 1, Int2: 2, Limit: 5, Str1: "A", Str2: "B"}
@@ -65,7 +67,7 @@ func Test_StatsExAequo(t *testing.T) {
 
 func Test_SaveStatsLargeConcurrency(t *testing.T) {
 	assert := assert.New(t)
-	recorder := NewFizzBuzzStatsController(&mockLogger{})
+	recorder := NewFizzBuzzStatsController(StatsKeyingExact, &mockLogger{})
 	req := types.FizzBuzzRequest{Int1: 3, Int2: 5, Limit: 15, Str1: "Fizz", Str2: "Buzz"}
 	concurrency := 1000
 	done := make(chan bool)
@@ -88,7 +90,7 @@ func Test_SaveStatsLargeConcurrency(t *testing.T) {
 
 func Test_SaveAndGetStatsConcurrency(t *testing.T) {
 	assert := assert.New(t)
-	recorder := NewFizzBuzzStatsController(&mockLogger{})
+	recorder := NewFizzBuzzStatsController(StatsKeyingExact, &mockLogger{})
 	req := types.FizzBuzzRequest{Int1: 3, Int2: 5, Limit: 15, Str1: "Fizz", Str2: "Buzz"}
 	concurrency := 1000
 	done := make(chan bool)
@@ -117,7 +119,7 @@ func Test_SaveAndGetStatsConcurrency(t *testing.T) {
 // Test that reversed requests are not considered equal, and their counts are not aggregated
 func Test_ReversedRequestsAreNotEqual(t *testing.T) {
 	assert := assert.New(t)
-	recorder := NewFizzBuzzStatsController(&mockLogger{})
+	recorder := NewFizzBuzzStatsController(StatsKeyingExact, &mockLogger{})
 	req1 := types.FizzBuzzRequest{Int1: 3, Int2: 5, Limit: 15, Str1: "Fizz", Str2: "Buzz"}
 	req2 := types.FizzBuzzRequest{Int1: 5, Int2: 3, Limit: 15, Str1: "Buzz", Str2: "Fizz"}
 	// Save both requests
@@ -129,3 +131,129 @@ func Test_ReversedRequestsAreNotEqual(t *testing.T) {
 	assert.Equal(1, stats.Count)
 	assert.Len(stats.MostFrequentRequests, 2)
 }
+
+func Test_SaveStat_NormalizesStrings(t *testing.T) {
+	assert := assert.New(t)
+	recorder := NewFizzBuzzStatsController(StatsKeyingExact, &mockLogger{})
+	recorder.SaveStat(types.FizzBuzzRequest{Int1: 3, Int2: 5, Limit: 15, Str1: "caf\u00e9", Str2: "Buzz"})  // Precomposed
+	recorder.SaveStat(types.FizzBuzzRequest{Int1: 3, Int2: 5, Limit: 15, Str1: "cafe\u0301", Str2: "Buzz"}) // Combining accent
+
+	stats := recorder.GetStats()
+	assert.Equal(2, stats.Count)
+	assert.Equal([]types.FizzBuzzRequest{{Int1: 3, Int2: 5, Limit: 15, Str1: "caf\u00e9", Str2: "Buzz"}}, stats.MostFrequentRequests)
+
+	str1 := "cafe\u0301"
+	assert.Equal(1, recorder.ResetStats(types.FizzBuzzStatsFilter{Str1: &str1}))
+	assert.Equal("cafe\u0301", str1, "the filter of the caller is left as is")
+}
+
+func Test_ResetStats_All(t *testing.T) {
+	assert := assert.New(t)
+	recorder := NewFizzBuzzStatsController(StatsKeyingExact, &mockLogger{})
+	recorder.SaveStat(types.FizzBuzzRequest{Int1: 3, Int2: 5, Limit: 15, Str1: "Fizz", Str2: "Buzz"})
+	recorder.SaveStat(types.FizzBuzzRequest{Int1: 2, Int2: 4, Limit: 10, Str1: "Foo", Str2: "Bar"})
+
+	removed := recorder.ResetStats(types.FizzBuzzStatsFilter{})
+	assert.Equal(2, removed)
+
+	stats := recorder.GetStats()
+	assert.Equal(0, stats.Count)
+	assert.Empty(stats.MostFrequentRequests)
+}
+
+func Test_ResetStats_Filter(t *testing.T) {
+	assert := assert.New(t)
+	recorder := NewFizzBuzzStatsController(StatsKeyingExact, &mockLogger{})
+	req1 := types.FizzBuzzRequest{Int1: 3, Int2: 5, Limit: 15, Str1: "Fizz", Str2: "Buzz"}
+	req2 := types.FizzBuzzRequest{Int1: 3, Int2: 5, Limit: 100, Str1: "Fizz", Str2: "Buzz"}
+	req3 := types.FizzBuzzRequest{Int1: 2, Int2: 4, Limit: 10, Str1: "Foo", Str2: "Bar"}
+	recorder.SaveStat(req1)
+	recorder.SaveStat(req2)
+	recorder.SaveStat(req3)
+
+	str1 := "Fizz"
+	removed := recorder.ResetStats(types.FizzBuzzStatsFilter{Str1: &str1})
+	assert.Equal(2, removed)
+
+	stats := recorder.GetStats()
+	assert.Equal(1, stats.Count)
+	assert.Equal([]types.FizzBuzzRequest{req3}, stats.MostFrequentRequests)
+}
+
+func Test_PruneStats(t *testing.T) {
+	assert := assert.New(t)
+	recorder := NewFizzBuzzStatsController(StatsKeyingExact, &mockLogger{})
+	now := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	req1 := types.FizzBuzzRequest{Int1: 3, Int2: 5, Limit: 15, Str1: "Fizz", Str2: "Buzz"}
+	req2 := types.FizzBuzzRequest{Int1: 2, Int2: 4, Limit: 10, Str1: "Foo", Str2: "Bar"}
+
+	recorder.now = func() time.Time { return now.AddDate(0, 0, -5) }
+	recorder.SaveStat(req1)
+	recorder.SaveStat(req1)
+	recorder.now = func() time.Time { return now }
+	recorder.SaveStat(req2)
+
+	removed := recorder.PruneStats(now.AddDate(0, 0, -2))
+	assert.Equal(1, removed)
+
+	stats := recorder.GetStats()
+	assert.Equal(1, stats.Count)
+	assert.Equal([]types.FizzBuzzRequest{req2}, stats.MostFrequentRequests)
+}
+
+func Test_GetSummary(t *testing.T) {
+	assert := assert.New(t)
+	recorder := NewFizzBuzzStatsController(StatsKeyingExact, &mockLogger{})
+	req1 := types.FizzBuzzRequest{Int1: 3, Int2: 5, Limit: 15, Str1: "Fizz", Str2: "Buzz"}
+	req2 := types.FizzBuzzRequest{Int1: 3, Int2: 5, Limit: 1000, Str1: "Foo", Str2: "Bar"}
+	req3 := types.FizzBuzzRequest{Int1: 2, Int2: 7, Limit: 5, Str1: "Fizz", Str2: "Buzz"}
+	recorder.SaveStat(req1)
+	recorder.SaveStat(req1)
+	recorder.SaveStat(req2)
+	recorder.SaveStat(req3)
+	recorder.SaveError(ErrorCode(ErrLimitExceeded))
+	recorder.SaveError(ErrorCode(ErrLimitExceeded))
+	recorder.SaveError(ErrCodeInvalidRequest)
+
+	summary := recorder.GetSummary()
+	assert.Equal(4, summary.TotalRequests)
+	assert.Equal(3, summary.UniqueRequests)
+	assert.Equal(map[string]int{ErrCodeLimitExceeded: 2, ErrCodeInvalidRequest: 1}, summary.ErrorRequests)
+	assert.Equal([]types.FizzBuzzLimitBucket{
+		{Min: 1, Max: 10, Count: 1},
+		{Min: 11, Max: 100, Count: 2},
+		{Min: 101, Max: 1000, Count: 1},
+	}, summary.LimitHistogram)
+	assert.Equal([]types.FizzBuzzDivisorPair{
+		{Int1: 3, Int2: 5, Count: 3},
+		{Int1: 2, Int2: 7, Count: 1},
+	}, summary.TopDivisorPairs)
+	assert.Equal([]types.FizzBuzzStringPair{
+		{Str1: "Fizz", Str2: "Buzz", Count: 3},
+		{Str1: "Foo", Str2: "Bar", Count: 1},
+	}, summary.TopStringPairs)
+}
+
+func Test_ResetStats_ClearsErrors(t *testing.T) {
+	assert := assert.New(t)
+	recorder := NewFizzBuzzStatsController(StatsKeyingExact, &mockLogger{})
+	recorder.SaveError(ErrCodeInvalidRequest)
+
+	recorder.ResetStats(types.FizzBuzzStatsFilter{})
+	assert.Empty(recorder.GetSummary().ErrorRequests)
+}
+
+func Test_Ping(t *testing.T) {
+	assert := assert.New(t)
+	recorder := NewFizzBuzzStatsController(StatsKeyingExact, &mockLogger{})
+	assert.NoError(recorder.Ping(context.Background()))
+
+	recorder.Lock()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Error(recorder.Ping(ctx))
+
+	// The record is pinged again once released
+	time.AfterFunc(10*time.Millisecond, recorder.Unlock)
+	assert.NoError(recorder.Ping(context.Background()))
+}
@@ -0,0 +1,114 @@
+package controllers
+
+import (
+	"fizzbuzz-api/internal/fizzbuzzapi/types"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Units of FizzBuzzLimits.StringLengthUnit, in which string lengths are counted once normalized to NFC.
+// Bytes are counted when the unit is empty.
+const (
+	StringLengthBytes     = "bytes"
+	StringLengthRunes     = "runes"
+	StringLengthGraphemes = "graphemes" // User-perceived characters: an emoji, a letter with its accents or a flag count as 1
+)
+
+// Reasons of types.StringViolation
+const (
+	ViolationControl   = "control"
+	ViolationBidi      = "bidi"
+	ViolationZeroWidth = "zero_width"
+)
+
+const zwj = '\u200d' // Zero width joiner, joining emoji into a single one
+
+// UnsafeCharactersError lists the characters of str1 and str2 that could hide or reorder the text displayed around them
+type UnsafeCharactersError struct {
+	Violations []types.StringViolation
+}
+
+func (e *UnsafeCharactersError) Error() string {
+	details := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		details[i] = fmt.Sprintf("%s: %s (%s) at offset %d", v.Field, v.Character, v.Reason, v.Offset)
+	}
+	return ErrUnsafeCharacter.Error() + ": " + strings.Join(details, "; ")
+}
+
+func (e *UnsafeCharactersError) Unwrap() error {
+	return ErrUnsafeCharacter
+}
+
+// checkCharacters reports the control, bidi and zero-width characters of str1 and str2
+func checkCharacters(str1, str2 string) error {
+	violations := append(unsafeCharacters("str1", str1), unsafeCharacters("str2", str2)...)
+	if len(violations) > 0 {
+		return &UnsafeCharactersError{Violations: violations}
+	}
+	return nil
+}
+
+// unsafeCharacters returns the control, bidi and zero-width characters of s. Zero width joiners are allowed
+// between emoji, as they join them into a single one.
+func unsafeCharacters(field, s string) []types.StringViolation {
+	var violations []types.StringViolation
+	for offset, r := range s {
+		reason := ""
+		switch {
+		case unicode.IsControl(r):
+			reason = ViolationControl
+		case isBidiControl(r):
+			reason = ViolationBidi
+		case r == zwj && joinsEmoji(s, offset):
+		case isZeroWidth(r):
+			reason = ViolationZeroWidth
+		}
+		if reason != "" {
+			violations = append(violations, types.StringViolation{Field: field, Offset: offset, Character: fmt.Sprintf("U+%04X", r), Reason: reason})
+		}
+	}
+	return violations
+}
+
+func isBidiControl(r rune) bool {
+	return r == '\u061c' || r == '\u200e' || r == '\u200f' || (r >= '\u202a' && r <= '\u202e') || (r >= '\u2066' && r <= '\u2069')
+}
+
+func isZeroWidth(r rune) bool {
+	return r == '\u200b' || r == '\u200c' || r == zwj || r == '\u2060' || r == '\ufeff'
+}
+
+// joinsEmoji reports whether the zero width joiner at offset joins two emoji: it then sits within a grapheme
+// cluster rather than ending one
+func joinsEmoji(s string, offset int) bool {
+	start, state := 0, -1
+	for rest := s; rest != ""; {
+		var cluster string
+		cluster, rest, _, state = uniseg.FirstGraphemeClusterInString(rest, state)
+		start += len(cluster)
+		if start > offset {
+			return start > offset+len(string(zwj))
+		}
+	}
+	return false
+}
+
+// stringLength returns the length of s in the unit once normalized to NFC, so that an accented letter has
+// the same length whether it is sent precomposed or followed by a combining accent
+func stringLength(s, unit string) int {
+	s = norm.NFC.String(s)
+	switch unit {
+	case StringLengthRunes:
+		return utf8.RuneCountInString(s)
+	case StringLengthGraphemes:
+		return uniseg.GraphemeClusterCount(s)
+	default:
+		return len(s)
+	}
+}
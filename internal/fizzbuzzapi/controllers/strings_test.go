@@ -0,0 +1,97 @@
+package controllers
+
+import (
+	"fizzbuzz-api/internal/fizzbuzzapi/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_StringLength(t *testing.T) {
+	tests := []struct {
+		name                    string
+		s                       string
+		bytes, runes, graphemes int
+	}{
+		{"ascii", "fizz", 4, 4, 4},
+		{"emoji", "🍕🍕", 8, 2, 2},
+		{"precomposed accent", "café", 5, 4, 4},
+		{"combining accent, normalized", "cafe\u0301", 5, 4, 4},
+		{"combining marks without precomposed form", "q\u0307\u0323", 5, 3, 1},
+		{"skin tone", "👋\U0001f3fd", 8, 2, 1},
+		{"variation selector", "❤\ufe0f", 6, 2, 1},
+		{"emoji sequence", "👩\u200d💻", 11, 3, 1},
+		{"family", "👨\u200d👩\u200d👧\u200d👦", 25, 7, 1},
+		{"flags", "🇫🇷🇪🇸🇺", 20, 5, 3},
+		{"hangul jamo composed by NFC", "\u1100\u1161\u11a8", 3, 1, 1},
+		{"archaic hangul jamo", "\u1113\u1161\u11a8", 9, 3, 1},
+		{"hangul syllables", "한국", 6, 2, 2},
+		{"devanagari spacing mark", "क\u093f", 6, 2, 1},
+		{"CR LF", "\r\n", 2, 2, 1},
+		{"joiner without emoji", "a\u200db", 5, 3, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			assert.Equal(tt.bytes, stringLength(tt.s, StringLengthBytes))
+			assert.Equal(tt.bytes, stringLength(tt.s, ""), "bytes by default")
+			assert.Equal(tt.runes, stringLength(tt.s, StringLengthRunes))
+			assert.Equal(tt.graphemes, stringLength(tt.s, StringLengthGraphemes))
+		})
+	}
+}
+
+func Test_CheckCharacters(t *testing.T) {
+	tests := []struct {
+		name       string
+		str1, str2 string
+		violations []types.StringViolation
+	}{
+		{"plain", "fizz", "buzz", nil},
+		{"emoji and accents", "👩\u200d💻 café", "❤\ufe0f\u200d🔥", nil},
+		{"control", "fizz\n", "\x00buzz", []types.StringViolation{
+			{Field: "str1", Offset: 4, Character: "U+000A", Reason: ViolationControl},
+			{Field: "str2", Offset: 0, Character: "U+0000", Reason: ViolationControl},
+		}},
+		{"bidi", "\u202efizz", "buzz\u2066", []types.StringViolation{
+			{Field: "str1", Offset: 0, Character: "U+202E", Reason: ViolationBidi},
+			{Field: "str2", Offset: 4, Character: "U+2066", Reason: ViolationBidi},
+		}},
+		{"zero width", "fi\u200bzz", "buzz\ufeff", []types.StringViolation{
+			{Field: "str1", Offset: 2, Character: "U+200B", Reason: ViolationZeroWidth},
+			{Field: "str2", Offset: 4, Character: "U+FEFF", Reason: ViolationZeroWidth},
+		}},
+		{"joiner outside emoji", "fi\u200dzz", "🍕\u200d", []types.StringViolation{
+			{Field: "str1", Offset: 2, Character: "U+200D", Reason: ViolationZeroWidth},
+			{Field: "str2", Offset: 4, Character: "U+200D", Reason: ViolationZeroWidth},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkCharacters(tt.str1, tt.str2)
+			if tt.violations == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var unsafe *UnsafeCharactersError
+			if assert.ErrorAs(t, err, &unsafe) {
+				assert.Equal(t, tt.violations, unsafe.Violations)
+				assert.ErrorIs(t, err, ErrUnsafeCharacter)
+				assert.Equal(t, ErrCodeInvalidParameter, ErrorCode(err))
+			}
+		})
+	}
+}
+
+func Test_GenerateFizzBuzz_StringLengthUnits(t *testing.T) {
+	req := types.FizzBuzzRequest{Int1: 3, Int2: 5, Limit: 3, Str1: "🍕🍕🍕", Str2: "buzz"}
+	for unit, err := range map[string]error{
+		StringLengthBytes:     ErrStringLengthExceeded,
+		StringLengthRunes:     nil,
+		StringLengthGraphemes: nil,
+	} {
+		ctrl := NewFizzBuzzController(types.FizzBuzzLimits{MaxLimit: 10, MaxStringLength: 4, StringLengthUnit: unit}, &mockLogger{})
+		_, genErr := ctrl.GenerateFizzBuzz(req)
+		assert.ErrorIs(t, genErr, err, unit)
+	}
+}
@@ -0,0 +1,81 @@
+package controllers
+
+import (
+	"fizzbuzz-api/internal/fizzbuzzapi/types"
+	"iter"
+	"time"
+)
+
+// Number of hunks listed by types.FizzBuzzDiff, further runs of mismatches being counted only
+const maxDiffHunks = 10
+
+// Verify validates the request like GenerateFizzBuzz, and grades the submitted sequence against it.
+// Expected values are generated as the submission is consumed, so neither sequence is held in memory.
+// Submissions are bound by the max limit, one extra value being enough to fail them.
+func (ctrl *FizzBuzzController) Verify(req types.FizzBuzzRequest, submitted iter.Seq[string]) (types.FizzBuzzVerifyResponse, error) {
+	expected, _, err := ctrl.StreamExplained(req)
+	if err != nil {
+		return types.FizzBuzzVerifyResponse{}, err
+	}
+	maxSubmitted := ctrl.Limits().MaxLimit + 1
+
+	start := time.Now()
+	next, stop := iter.Pull(expected)
+	defer stop()
+
+	g := grader{resp: types.FizzBuzzVerifyResponse{Diff: types.FizzBuzzDiff{ByToken: map[string]int{}, Hunks: []types.FizzBuzzDiffHunk{}}}}
+	for actual := range submitted {
+		if g.resp.Submitted == maxSubmitted {
+			return types.FizzBuzzVerifyResponse{}, ErrLimitExceeded
+		}
+		g.resp.Submitted++
+		want, ok := next()
+		if !ok {
+			g.resp.Diff.Extra++
+			g.mismatch(g.resp.Submitted, nil, &actual)
+			continue
+		}
+		g.resp.Expected++
+		if want.Value != actual {
+			g.resp.Diff.Changed++
+			g.resp.Diff.ByToken[want.Token]++
+			g.mismatch(want.Index, &want.Value, &actual)
+		}
+	}
+	for want, ok := next(); ok; want, ok = next() {
+		g.resp.Expected++
+		g.resp.Diff.Missing++
+		g.resp.Diff.ByToken[want.Token]++
+		g.mismatch(want.Index, &want.Value, nil)
+	}
+	g.resp.Pass = g.resp.Mismatches == 0
+	duration := time.Since(start)
+	g.resp.Duration = duration.Milliseconds()
+	ctrl.log.Info("fizzBuzz verified", "length", g.resp.Expected, "submitted", g.resp.Submitted, "mismatches", g.resp.Mismatches, "duration_ms", duration.Milliseconds())
+
+	return g.resp, nil
+}
+
+// grader records the mismatches of a submission, in increasing positions
+type grader struct {
+	resp types.FizzBuzzVerifyResponse
+	last int // Position of the last mismatch
+}
+
+func (g *grader) mismatch(index int, expected, actual *string) {
+	g.resp.Mismatches++
+	if g.resp.FirstMismatch == nil {
+		g.resp.FirstMismatch = &types.FizzBuzzMismatch{Index: index, Expected: expected, Actual: actual}
+	}
+
+	hunks := g.resp.Diff.Hunks
+	switch {
+	case len(hunks) > 0 && g.last == index-1:
+		if g.last == hunks[len(hunks)-1].End {
+			hunks[len(hunks)-1].End = index
+		}
+	case len(hunks) < maxDiffHunks:
+		g.resp.Diff.Hunks = append(hunks, types.FizzBuzzDiffHunk{Start: index, End: index})
+	}
+	g.last = index
+}
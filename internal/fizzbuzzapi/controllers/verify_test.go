@@ -0,0 +1,94 @@
+package controllers
+
+import (
+	"fizzbuzz-api/internal/fizzbuzzapi/types"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Verify(t *testing.T) {
+	ctrl := NewFizzBuzzController(types.FizzBuzzLimits{
+		MaxLimit:        100,
+		MaxStringLength: 100,
+	}, &mockLogger{})
+	req := types.FizzBuzzRequest{Int1: 3, Int2: 5, Limit: 6, Str1: "Fizz", Str2: "Buzz"}
+	str := func(s string) *string { return &s }
+
+	tests := []struct {
+		name      string
+		submitted []string
+		mismatch  *types.FizzBuzzMismatch
+		diff      types.FizzBuzzDiff
+	}{
+		{"pass", []string{"1", "2", "Fizz", "4", "Buzz", "Fizz"}, nil,
+			types.FizzBuzzDiff{ByToken: map[string]int{}, Hunks: []types.FizzBuzzDiffHunk{}}},
+		{"changed", []string{"1", "2", "Buzz", "4", "Fizz", "Fizz"}, &types.FizzBuzzMismatch{Index: 3, Expected: str("Fizz"), Actual: str("Buzz")},
+			types.FizzBuzzDiff{Changed: 2, ByToken: map[string]int{types.TokenStr1: 1, types.TokenStr2: 1}, Hunks: []types.FizzBuzzDiffHunk{{Start: 3, End: 3}, {Start: 5, End: 5}}}},
+		{"missing", []string{"1", "2", "Fizz", "4"}, &types.FizzBuzzMismatch{Index: 5, Expected: str("Buzz")},
+			types.FizzBuzzDiff{Missing: 2, ByToken: map[string]int{types.TokenStr1: 1, types.TokenStr2: 1}, Hunks: []types.FizzBuzzDiffHunk{{Start: 5, End: 6}}}},
+		{"extra", []string{"1", "2", "Fizz", "4", "Buzz", "Fizz", "7", ""}, &types.FizzBuzzMismatch{Index: 7, Actual: str("7")},
+			types.FizzBuzzDiff{Extra: 2, ByToken: map[string]int{}, Hunks: []types.FizzBuzzDiffHunk{{Start: 7, End: 8}}}},
+		{"empty", nil, &types.FizzBuzzMismatch{Index: 1, Expected: str("1")},
+			types.FizzBuzzDiff{Missing: 6, ByToken: map[string]int{types.TokenNumber: 3, types.TokenStr1: 2, types.TokenStr2: 1}, Hunks: []types.FizzBuzzDiffHunk{{Start: 1, End: 6}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			resp, err := ctrl.Verify(req, slices.Values(tt.submitted))
+			assert.NoError(err)
+
+			assert.Equal(tt.mismatch == nil, resp.Pass)
+			assert.Equal(6, resp.Expected)
+			assert.Equal(len(tt.submitted), resp.Submitted)
+			assert.Equal(tt.diff.Changed+tt.diff.Missing+tt.diff.Extra, resp.Mismatches)
+			assert.Equal(tt.mismatch, resp.FirstMismatch)
+			assert.Equal(tt.diff, resp.Diff)
+		})
+	}
+}
+
+func Test_Verify_LimitExceeded(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := NewFizzBuzzController(types.FizzBuzzLimits{MaxLimit: 10, MaxStringLength: 10}, &mockLogger{})
+	req := types.FizzBuzzRequest{Int1: 3, Int2: 5, Limit: 10, Str1: "Fizz", Str2: "Buzz"}
+	full, _ := ctrl.GenerateFizzBuzz(req)
+
+	// One extra value is graded, more are not read
+	resp, err := ctrl.Verify(req, slices.Values(append(full.Result, "11")))
+	assert.NoError(err)
+	assert.Equal(1, resp.Diff.Extra)
+	read := 0
+	_, err = ctrl.Verify(req, func(yield func(string) bool) {
+		for read = 1; yield("1"); read++ {
+		}
+	})
+	assert.Equal(ErrLimitExceeded, err)
+	assert.Equal(12, read)
+}
+
+func Test_Verify_Hunks(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := NewFizzBuzzController(types.FizzBuzzLimits{
+		MaxLimit:        100,
+		MaxStringLength: 100,
+	}, &mockLogger{})
+
+	// Every multiple of 3 is wrong, giving one hunk per mismatch past the listed ones
+	resp, err := ctrl.Verify(types.FizzBuzzRequest{Int1: 3, Int2: 100, Limit: 100, Str1: "Fizz", Str2: "Buzz"}, func(yield func(string) bool) {
+		for i := 1; i <= 100; i++ {
+			if !yield(valueAt(3, 100, "Fuzz", "Buzz", i)) {
+				return
+			}
+		}
+	})
+	assert.NoError(err)
+	assert.False(resp.Pass)
+	assert.Equal(33, resp.Mismatches)
+	assert.Len(resp.Diff.Hunks, maxDiffHunks)
+	assert.Equal(types.FizzBuzzDiffHunk{Start: 30, End: 30}, resp.Diff.Hunks[maxDiffHunks-1])
+
+	_, err = ctrl.Verify(types.FizzBuzzRequest{Int1: 3, Int2: 5, Limit: 101, Str1: "Fizz", Str2: "Buzz"}, nil)
+	assert.Equal(ErrLimitExceeded, err)
+}
@@ -0,0 +1,116 @@
+package encoders
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+
+	"github.com/ugorji/go/codec"
+	"google.golang.org/protobuf/proto"
+)
+
+// Encoder serializes response values to a media type
+type Encoder interface {
+	ContentType() string
+	// Accepts reports whether the encoder can serialize v
+	Accepts(v any) bool
+	Encode(w io.Writer, v any) error
+}
+
+// Lister is implemented by values made of a sequence of strings, which text encoders write one per row
+type Lister interface {
+	Values() []string
+}
+
+// JSON encodes any value, like gin's c.JSON
+type JSON struct{}
+
+func (JSON) ContentType() string { return "application/json; charset=utf-8" }
+func (JSON) Accepts(v any) bool  { return true }
+func (JSON) Encode(w io.Writer, v any) error {
+	return json.NewEncoder(w).Encode(v)
+}
+
+// Text writes the values of a Lister one per line
+type Text struct{}
+
+func (Text) ContentType() string { return "text/plain; charset=utf-8" }
+func (Text) Accepts(v any) bool  { _, ok := v.(Lister); return ok }
+func (Text) Encode(w io.Writer, v any) error {
+	for _, value := range v.(Lister).Values() {
+		if _, err := io.WriteString(w, value+"\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CSV writes the values of a Lister as index,value rows, indexes starting at 1
+type CSV struct{}
+
+func (CSV) ContentType() string { return "text/csv; charset=utf-8" }
+func (CSV) Accepts(v any) bool  { _, ok := v.(Lister); return ok }
+func (CSV) Encode(w io.Writer, v any) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"index", "value"})
+	for i, value := range v.(Lister).Values() {
+		cw.Write([]string{strconv.Itoa(i + 1), value})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// MessagePack encodes any value, with the field names of its json tags
+type MessagePack struct{}
+
+var msgpackHandle = &codec.MsgpackHandle{}
+
+func (MessagePack) ContentType() string { return "application/msgpack" }
+func (MessagePack) Aliases() []string   { return []string{"application/x-msgpack"} }
+func (MessagePack) Accepts(v any) bool  { return true }
+func (MessagePack) Encode(w io.Writer, v any) error {
+	return codec.NewEncoder(w, msgpackHandle).Encode(v)
+}
+
+// Protobuf encodes protobuf messages, and values of the types a converter is registered for with AddProtoConverter
+type Protobuf struct {
+	converters map[reflect.Type]func(any) proto.Message
+}
+
+func NewProtobuf() *Protobuf {
+	return &Protobuf{converters: map[reflect.Type]func(any) proto.Message{}}
+}
+
+// AddProtoConverter makes p encode values of type T as the message returned by convert
+func AddProtoConverter[T any](p *Protobuf, convert func(T) proto.Message) {
+	p.converters[reflect.TypeFor[T]()] = func(v any) proto.Message { return convert(v.(T)) }
+}
+
+func (p *Protobuf) ContentType() string { return "application/x-protobuf" }
+func (p *Protobuf) Aliases() []string   { return []string{"application/protobuf"} }
+func (p *Protobuf) Accepts(v any) bool {
+	if _, ok := v.(proto.Message); ok {
+		return true
+	}
+	_, ok := p.converters[reflect.TypeOf(v)]
+	return ok
+}
+func (p *Protobuf) Encode(w io.Writer, v any) error {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		convert, ok := p.converters[reflect.TypeOf(v)]
+		if !ok {
+			return fmt.Errorf("no protobuf message for %T", v)
+		}
+		msg = convert(v)
+	}
+	b, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
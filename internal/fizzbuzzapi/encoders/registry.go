@@ -0,0 +1,139 @@
+package encoders
+
+import (
+	"errors"
+	"mime"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Returned by Negotiate when no registered encoder matches the Accept header
+var ErrNotAcceptable = errors.New("no acceptable representation")
+
+// Optional interface for encoders answering to other media types than their content type
+type aliased interface {
+	Aliases() []string
+}
+
+// Registry selects the encoder of a response from the Accept header of the request
+type Registry struct {
+	encoders []Encoder
+}
+
+// NewRegistry returns a registry of the given encoders, the first one being used when any type is accepted
+func NewRegistry(encoders ...Encoder) *Registry {
+	return &Registry{encoders: encoders}
+}
+
+func (r *Registry) Register(e Encoder) {
+	r.encoders = append(r.encoders, e)
+}
+
+// ContentTypes lists the content types of the registered encoders
+func (r *Registry) ContentTypes() []string {
+	types := make([]string, 0, len(r.encoders))
+	for _, e := range r.encoders {
+		types = append(types, e.ContentType())
+	}
+	return types
+}
+
+type mediaRange struct {
+	typ, subtype string
+	q            float64
+}
+
+// specificity ranks "type/subtype" before "type/*" before "*/*"
+func (m mediaRange) specificity() int {
+	switch {
+	case m.typ == "*":
+		return 0
+	case m.subtype == "*":
+		return 1
+	default:
+		return 2
+	}
+}
+
+func (m mediaRange) matches(mediaType string) bool {
+	typ, subtype, _ := strings.Cut(mediaType, "/")
+	return (m.typ == "*" || m.typ == typ) && (m.subtype == "*" || m.subtype == subtype)
+}
+
+// Negotiate returns the encoder of v preferred by the Accept header, following the q-values, the specificity of
+// the media ranges, and the registration order. An empty header accepts any type.
+func (r *Registry) Negotiate(accept string, v any) (Encoder, error) {
+	ranges := parseAccept(accept)
+	for _, m := range ranges {
+		if m.q <= 0 {
+			// Media ranges are sorted, nothing acceptable is left
+			break
+		}
+		for _, e := range r.encoders {
+			if e.Accepts(v) && acceptsMediaType(m, e) && !excluded(ranges, e) {
+				return e, nil
+			}
+		}
+	}
+	return nil, ErrNotAcceptable
+}
+
+func acceptsMediaType(m mediaRange, e Encoder) bool {
+	mediaType, _, _ := mime.ParseMediaType(e.ContentType())
+	if m.matches(mediaType) {
+		return true
+	}
+	if a, ok := e.(aliased); ok {
+		return slices.ContainsFunc(a.Aliases(), m.matches)
+	}
+	return false
+}
+
+// excluded reports whether the most specific range matching the encoder has q=0, e.g. "*/*, text/csv;q=0"
+func excluded(ranges []mediaRange, e Encoder) bool {
+	var best *mediaRange
+	for i, m := range ranges {
+		if acceptsMediaType(m, e) && (best == nil || m.specificity() > best.specificity()) {
+			best = &ranges[i]
+		}
+	}
+	return best != nil && best.q <= 0
+}
+
+// parseAccept parses the Accept header, sorted by decreasing preference
+func parseAccept(accept string) []mediaRange {
+	if strings.TrimSpace(accept) == "" {
+		return []mediaRange{{typ: "*", subtype: "*", q: 1}}
+	}
+
+	var ranges []mediaRange
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		typ, subtype, ok := strings.Cut(mediaType, "/")
+		if !ok {
+			continue
+		}
+		q := 1.0
+		if value, ok := params["q"]; ok {
+			if parsed, err := strconv.ParseFloat(value, 64); err == nil && parsed >= 0 && parsed <= 1 {
+				q = parsed
+			}
+		}
+		ranges = append(ranges, mediaRange{typ: typ, subtype: subtype, q: q})
+	}
+
+	slices.SortStableFunc(ranges, func(a, b mediaRange) int {
+		if a.q != b.q {
+			if a.q > b.q {
+				return -1
+			}
+			return 1
+		}
+		return b.specificity() - a.specificity()
+	})
+	return ranges
+}
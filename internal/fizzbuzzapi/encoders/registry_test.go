@@ -0,0 +1,89 @@
+package encoders
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type values []string
+
+func (v values) Values() []string { return v }
+
+func newTestRegistry() *Registry {
+	protobuf := NewProtobuf()
+	AddProtoConverter(protobuf, func(v values) proto.Message { return wrapperspb.String(v[0]) })
+	return NewRegistry(JSON{}, Text{}, CSV{}, MessagePack{}, protobuf)
+}
+
+func Test_Negotiate(t *testing.T) {
+	registry := newTestRegistry()
+
+	tests := []struct {
+		name   string
+		accept string
+		value  any
+		want   string
+	}{
+		{"empty header", "", values{"1"}, "application/json; charset=utf-8"},
+		{"any type", "*/*", values{"1"}, "application/json; charset=utf-8"},
+		{"exact type", "text/csv", values{"1"}, "text/csv; charset=utf-8"},
+		{"type with parameters", "text/plain; charset=utf-8", values{"1"}, "text/plain; charset=utf-8"},
+		{"alias", "application/x-msgpack", values{"1"}, "application/msgpack"},
+		{"protobuf alias", "application/protobuf", values{"1"}, "application/x-protobuf"},
+		{"q-values", "application/json;q=0.5, text/csv;q=0.8", values{"1"}, "text/csv; charset=utf-8"},
+		{"specific before wildcard", "*/*, text/plain", values{"1"}, "text/plain; charset=utf-8"},
+		{"subtype wildcard", "text/*", values{"1"}, "text/plain; charset=utf-8"},
+		{"excluded type", "text/*, text/plain;q=0", values{"1"}, "text/csv; charset=utf-8"},
+		{"unsupported value skipped", "text/plain, application/json;q=0.1", map[string]int{"a": 1}, "application/json; charset=utf-8"},
+		{"malformed range ignored", "nonsense, text/csv", values{"1"}, "text/csv; charset=utf-8"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := registry.Negotiate(tt.accept, tt.value)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.want, e.ContentType())
+			}
+		})
+	}
+}
+
+func Test_Negotiate_NotAcceptable(t *testing.T) {
+	registry := newTestRegistry()
+
+	for _, accept := range []string{"image/png", "*/*;q=0", "text/csv, application/*;q=0"} {
+		_, err := registry.Negotiate(accept, map[string]int{"a": 1})
+		assert.ErrorIs(t, err, ErrNotAcceptable, accept)
+	}
+}
+
+func Test_Encoders(t *testing.T) {
+	assert := assert.New(t)
+	var buf bytes.Buffer
+
+	assert.NoError(Text{}.Encode(&buf, values{"1", "fizz"}))
+	assert.Equal("1\nfizz\n", buf.String())
+
+	buf.Reset()
+	assert.NoError(CSV{}.Encode(&buf, values{"1", "fizz,buzz"}))
+	assert.Equal("index,value\n1,1\n2,\"fizz,buzz\"\n", buf.String())
+
+	buf.Reset()
+	assert.NoError(MessagePack{}.Encode(&buf, struct {
+		Result []string `json:"result"`
+	}{[]string{"1"}}))
+	assert.Equal([]byte{0x81, 0xa6, 'r', 'e', 's', 'u', 'l', 't', 0x91, 0xa1, '1'}, buf.Bytes())
+
+	p := NewProtobuf()
+	assert.False(p.Accepts(values{"1"}))
+	assert.True(p.Accepts(wrapperspb.String("1")))
+	AddProtoConverter(p, func(v values) proto.Message { return wrapperspb.String(v[0]) })
+	buf.Reset()
+	assert.NoError(p.Encode(&buf, values{"1"}))
+	decoded := &wrapperspb.StringValue{}
+	assert.NoError(proto.Unmarshal(buf.Bytes(), decoded))
+	assert.Equal("1", decoded.Value)
+}
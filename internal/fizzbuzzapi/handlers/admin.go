@@ -0,0 +1,23 @@
+package handlers
+
+import (
+	"fizzbuzz-api/internal/fizzbuzzapi/types"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ConfigReloadHistory interface {
+	History() []types.ConfigReload
+}
+
+// GetConfigReloads lists the last config reload attempts, applied or rejected
+func GetConfigReloads(history ConfigReloadHistory) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		reloads := history.History()
+		if reloads == nil {
+			reloads = []types.ConfigReload{}
+		}
+		respond(c, http.StatusOK, gin.H{"reloads": reloads})
+	}
+}
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"errors"
+	"fizzbuzz-api/internal/fizzbuzzapi/controllers"
+	"fizzbuzz-api/internal/fizzbuzzapi/types"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Optional interface for generators answering questions about a sequence without generating it
+type FizzBuzzAnalyzer interface {
+	ValueAt(req types.FizzBuzzAtRequest) (types.FizzBuzzAtResponse, error)
+	Count(req types.FizzBuzzCountRequest) (types.FizzBuzzCountResponse, error)
+	Window(req types.FizzBuzzWindowRequest) (types.FizzBuzzWindowResponse, error)
+}
+
+// GetValueAt returns the value at position n, which may go beyond the max limit
+func (h *FizzBuzzHandler) GetValueAt(c *gin.Context) {
+	analyzer, ok := h.fbGenerator.(FizzBuzzAnalyzer)
+	if !ok {
+		respond(c, http.StatusNotImplemented, gin.H{"code": ErrCodeNotImplemented, "error": "generator does not support positional queries"})
+		return
+	}
+
+	var req types.FizzBuzzAtRequest
+	if !h.bindPositional(c, &req) {
+		return
+	}
+
+	resp, err := analyzer.ValueAt(req)
+	if err != nil {
+		h.analysisFailed(c, err)
+		return
+	}
+	respond(c, http.StatusOK, resp)
+}
+
+// GetCount returns how many positions up to the limit hold each kind of value, the limit may go beyond the max limit
+func (h *FizzBuzzHandler) GetCount(c *gin.Context) {
+	analyzer, ok := h.fbGenerator.(FizzBuzzAnalyzer)
+	if !ok {
+		respond(c, http.StatusNotImplemented, gin.H{"code": ErrCodeNotImplemented, "error": "generator does not support counts"})
+		return
+	}
+
+	var req types.FizzBuzzCountRequest
+	if !h.bindPositional(c, &req) {
+		return
+	}
+
+	resp, err := analyzer.Count(req)
+	if err != nil {
+		h.analysisFailed(c, err)
+		return
+	}
+	respond(c, http.StatusOK, resp)
+}
+
+// GetWindow returns the values from a start position, which may go beyond the max limit
+func (h *FizzBuzzHandler) GetWindow(c *gin.Context) {
+	analyzer, ok := h.fbGenerator.(FizzBuzzAnalyzer)
+	if !ok {
+		respond(c, http.StatusNotImplemented, gin.H{"code": ErrCodeNotImplemented, "error": "generator does not support windows"})
+		return
+	}
+
+	var req types.FizzBuzzWindowRequest
+	if !h.bindPositional(c, &req) {
+		return
+	}
+
+	resp, err := analyzer.Window(req)
+	if err != nil {
+		h.analysisFailed(c, err)
+		return
+	}
+	respond(c, http.StatusOK, resp)
+}
+
+// bindPositional binds the query of a positional request and responds when it fails. Integers with too many digits
+// are invalid parameters rather than malformed requests.
+func (h *FizzBuzzHandler) bindPositional(c *gin.Context, req any) bool {
+	err := c.ShouldBindQuery(req)
+	switch {
+	case err == nil:
+		return true
+	case errors.Is(err, controllers.ErrTooManyDigits):
+		h.analysisFailed(c, err)
+	default:
+		h.RejectRequest(c, err)
+	}
+	return false
+}
+
+func (h *FizzBuzzHandler) analysisFailed(c *gin.Context, err error) {
+	h.log.Error("failed to analyze FizzBuzz", "error", err, "path", c.FullPath())
+	code := controllers.ErrorCode(err)
+	h.saveError(code)
+	respond(c, statusOf(code).http, errorBody(c, code, err))
+}
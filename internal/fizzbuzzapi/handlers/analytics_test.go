@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"fizzbuzz-api/internal/fizzbuzzapi/controllers"
+	"fizzbuzz-api/internal/fizzbuzzapi/types"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func initMockGinQuery(target string) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", target, nil)
+	return c, w
+}
+
+func Test_GetValueAt(t *testing.T) {
+	assert := assert.New(t)
+	generator := controllers.NewFizzBuzzController(types.FizzBuzzLimits{MaxLimit: 100, MaxStringLength: 10}, &mockLogger{})
+	handler := NewFizzBuzzHandler(&mockConfig, &mockLogger{}, generator, mockStatsRecorder)
+
+	c, w := initMockGinQuery("/fizzbuzz/at?n=987654321&int1=3&int2=5&str1=fizz&str2=buzz")
+	handler.GetValueAt(c)
+	assert.Equal(200, w.Code)
+	assert.JSONEq(`{"n":"987654321","value":"fizz"}`, w.Body.String())
+
+	c, w = initMockGinQuery("/fizzbuzz/at?n=1000000000000000000000000000002&int1=3&int2=5&str1=fizz&str2=buzz")
+	handler.GetValueAt(c)
+	assert.Equal(200, w.Code)
+	assert.JSONEq(`{"n":"1000000000000000000000000000002","value":"fizz"}`, w.Body.String())
+
+	c, w = initMockGinQuery("/fizzbuzz/at?n=1&int1=3&int2=5&str1=fizzfizzfizz&str2=buzz")
+	handler.GetValueAt(c)
+	assert.Equal(422, w.Code)
+
+	c, w = initMockGinQuery("/fizzbuzz/at?n=1&int1=3&str1=fizz&str2=buzz")
+	handler.GetValueAt(c)
+	assert.Equal(400, w.Code)
+
+	c, w = initMockGinQuery("/fizzbuzz/at?n=" + strings.Repeat("9", types.MaxBigIntDigits+1) + "&int1=3&int2=5&str1=fizz&str2=buzz")
+	handler.GetValueAt(c)
+	assert.Equal(400, w.Code)
+	assert.Contains(w.Body.String(), `"code":"invalid_parameter"`)
+
+	c, w = initMockGinQuery("/fizzbuzz/at?n=1&int1=3&int2=5&str1=fizz&str2=buzz")
+	NewFizzBuzzHandler(&mockConfig, &mockLogger{}, mockFizzBuzzController, mockStatsRecorder).GetValueAt(c)
+	assert.Equal(501, w.Code)
+}
+
+func Test_GetCount(t *testing.T) {
+	assert := assert.New(t)
+	generator := controllers.NewFizzBuzzController(types.FizzBuzzLimits{MaxLimit: 100, MaxStringLength: 10}, &mockLogger{})
+	handler := NewFizzBuzzHandler(&mockConfig, &mockLogger{}, generator, mockStatsRecorder)
+
+	c, w := initMockGinQuery("/fizzbuzz/count?limit=9223372036854775807&int1=3&int2=5")
+	handler.GetCount(c)
+	assert.Equal(200, w.Code)
+	assert.Contains(w.Body.String(), `"str1str2":"614891469123651720"`)
+
+	c, w = initMockGinQuery("/fizzbuzz/count?limit=1000000000000000000000000000000&int1=3&int2=5")
+	handler.GetCount(c)
+	assert.Equal(200, w.Code)
+	assert.Contains(w.Body.String(), `"str1str2":"66666666666666666666666666666"`)
+
+	c, w = initMockGinQuery("/fizzbuzz/count?limit=1e30&int1=3&int2=5")
+	handler.GetCount(c)
+	assert.Equal(400, w.Code)
+}
+
+func Test_GetWindow(t *testing.T) {
+	assert := assert.New(t)
+	generator := controllers.NewFizzBuzzController(types.FizzBuzzLimits{MaxLimit: 100, MaxStringLength: 10}, &mockLogger{})
+	handler := NewFizzBuzzHandler(&mockConfig, &mockLogger{}, generator, mockStatsRecorder)
+
+	c, w := initMockGinQuery("/fizzbuzz/window?start=1000000000000000000000000000000&size=3&int1=3&int2=5&str1=fizz&str2=buzz")
+	handler.GetWindow(c)
+	assert.Equal(200, w.Code)
+	assert.JSONEq(`{"start":"1000000000000000000000000000000","result":["buzz","1000000000000000000000000000001","fizz"]}`, w.Body.String())
+
+	c, w = initMockGinQuery("/fizzbuzz/window?start=1&size=1000&int1=3&int2=5&str1=fizz&str2=buzz")
+	handler.GetWindow(c)
+	assert.Equal(422, w.Code)
+}
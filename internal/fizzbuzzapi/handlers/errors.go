@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"errors"
+	"fizzbuzz-api/internal/fizzbuzzapi/controllers"
+	"fizzbuzz-api/internal/fizzbuzzapi/i18n"
+	"fizzbuzz-api/internal/fizzbuzzapi/middleware"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	ErrCodeNotImplemented = "not_implemented" // Operation not supported by the stats storage
+	ErrCodeNotAcceptable  = "not_acceptable"  // No representation of the response matches the Accept header
+)
+
+// Statuses returned for each error code, shared by the REST and gRPC APIs
+type errorStatus struct {
+	http int
+	grpc codes.Code
+}
+
+var errorStatuses = map[string]errorStatus{
+	controllers.ErrCodeInvalidRequest:       {http.StatusBadRequest, codes.InvalidArgument},
+	controllers.ErrCodeInvalidParameter:     {http.StatusBadRequest, codes.InvalidArgument},
+	controllers.ErrCodeLimitExceeded:        {http.StatusUnprocessableEntity, codes.OutOfRange},
+	controllers.ErrCodeStringLengthExceeded: {http.StatusUnprocessableEntity, codes.OutOfRange},
+	// Errors the controller does not classify are answered 400, as they were before error codes were introduced
+	controllers.ErrCodeInternal: {http.StatusBadRequest, codes.InvalidArgument},
+}
+
+func statusOf(code string) errorStatus {
+	if status, ok := errorStatuses[code]; ok {
+		return status
+	}
+	return errorStatuses[controllers.ErrCodeInternal]
+}
+
+// errorBody returns the response to a failed request, with its message in the locale of the request,
+// and the characters at fault when str1 or str2 hold unsafe ones
+func errorBody(c *gin.Context, code string, err error) gin.H {
+	body := gin.H{"code": code, "error": i18n.TranslateError(middleware.Locale(c), err)}
+	var unsafe *controllers.UnsafeCharactersError
+	if errors.As(err, &unsafe) {
+		body["violations"] = unsafe.Violations
+	}
+	return body
+}
@@ -0,0 +1,214 @@
+package handlers
+
+import (
+	"context"
+	fizzbuzzv1 "fizzbuzz-api/api/proto/fizzbuzz/v1"
+	"fizzbuzz-api/internal/fizzbuzzapi/controllers"
+	"fizzbuzz-api/internal/fizzbuzzapi/i18n"
+	"fizzbuzz-api/internal/fizzbuzzapi/types"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Number of values per chunk of GenerateStream when the request does not set it
+const defaultChunkSize = 1000
+
+// FizzBuzzGRPCHandler serves the gRPC API with the generator, stats recorder and health checks of the REST API
+type FizzBuzzGRPCHandler struct {
+	fizzbuzzv1.UnimplementedFizzBuzzServiceServer
+
+	*FizzBuzzHandler
+	health *HealthHandler
+}
+
+func NewFizzBuzzGRPCHandler(fizzbuzzHandler *FizzBuzzHandler, health *HealthHandler) *FizzBuzzGRPCHandler {
+	return &FizzBuzzGRPCHandler{
+		FizzBuzzHandler: fizzbuzzHandler,
+		health:          health,
+	}
+}
+
+func (h *FizzBuzzGRPCHandler) Generate(ctx context.Context, protoReq *fizzbuzzv1.GenerateRequest) (*fizzbuzzv1.GenerateResponse, error) {
+	req, err := h.request(ctx, protoReq.GetRequest())
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := h.fbGenerator.GenerateFizzBuzz(req)
+	if err != nil {
+		return nil, h.generationFailed(ctx, err)
+	}
+	h.saveStat(req)
+	return &fizzbuzzv1.GenerateResponse{Result: result.Result, DurationMs: result.Duration}, nil
+}
+
+func (h *FizzBuzzGRPCHandler) GenerateStream(protoReq *fizzbuzzv1.GenerateStreamRequest, stream fizzbuzzv1.FizzBuzzService_GenerateStreamServer) error {
+	chunkSize := int(protoReq.GetChunkSize())
+	if chunkSize < 0 {
+		h.saveError(controllers.ErrCodeInvalidRequest)
+		return status.Errorf(codes.InvalidArgument, "chunk_size must be positive, got %d", chunkSize)
+	}
+	if chunkSize == 0 {
+		chunkSize = defaultChunkSize
+	}
+
+	req, err := h.request(stream.Context(), protoReq.GetRequest())
+	if err != nil {
+		return err
+	}
+	seq, err := h.sequence(req)
+	if err != nil {
+		return h.generationFailed(stream.Context(), err)
+	}
+	h.saveStat(req)
+
+	offset := 0
+	chunk := make([]string, 0, chunkSize)
+	send := func() error {
+		if err := stream.Send(&fizzbuzzv1.GenerateChunk{Offset: int64(offset), Values: chunk}); err != nil {
+			h.log.Error("failed to send FizzBuzz chunk", "error", err, "offset", offset)
+			return err
+		}
+		offset += len(chunk)
+		chunk = chunk[:0]
+		return nil
+	}
+	for value := range seq {
+		chunk = append(chunk, value)
+		if len(chunk) == chunkSize {
+			if err := send(); err != nil {
+				return err
+			}
+		}
+	}
+	if len(chunk) > 0 {
+		return send()
+	}
+	return nil
+}
+
+func (h *FizzBuzzGRPCHandler) GetStats(ctx context.Context, req *fizzbuzzv1.GetStatsRequest) (*fizzbuzzv1.GetStatsResponse, error) {
+	return toProtoStats(h.statsRecorder.GetStats()), nil
+}
+
+func (h *FizzBuzzGRPCHandler) Health(ctx context.Context, req *fizzbuzzv1.HealthRequest) (*fizzbuzzv1.HealthResponse, error) {
+	ready, checks := h.health.Ready(ctx)
+
+	resp := &fizzbuzzv1.HealthResponse{
+		Status: fizzbuzzv1.HealthResponse_STATUS_SERVING,
+		Checks: make(map[string]*fizzbuzzv1.CheckResult, len(checks)),
+	}
+	if !ready {
+		resp.Status = fizzbuzzv1.HealthResponse_STATUS_NOT_SERVING
+	}
+	for name, check := range checks {
+		resp.Checks[name] = &fizzbuzzv1.CheckResult{Ok: check.Status == "ok", Error: check.Error}
+	}
+	return resp, nil
+}
+
+// request converts and validates the request like the REST API does
+func (h *FizzBuzzGRPCHandler) request(ctx context.Context, protoReq *fizzbuzzv1.FizzBuzzRequest) (types.FizzBuzzRequest, error) {
+	req := fromProtoRequest(protoReq)
+	req.Locale = string(grpcLocale(ctx))
+	if err := binding.Validator.ValidateStruct(&req); err != nil {
+		h.log.Error("invalid gRPC request", "error", err)
+		h.saveError(controllers.ErrCodeInvalidRequest)
+		return req, status.Error(codes.InvalidArgument, err.Error())
+	}
+	h.log.Info("received FizzBuzz request", "request", req, "caller", grpcCaller(ctx))
+	return req, nil
+}
+
+// generationFailed records a generation error and maps it to its gRPC status,
+// with a message in the locale negotiated from the accept-language metadata
+func (h *FizzBuzzGRPCHandler) generationFailed(ctx context.Context, err error) error {
+	h.log.Error("failed to generate FizzBuzz", "error", err)
+	code := controllers.ErrorCode(err)
+	h.saveError(code)
+	return status.Error(statusOf(code).grpc, i18n.TranslateError(grpcLocale(ctx), err))
+}
+
+// grpcLocale negotiates the locale of a call from its accept-language metadata
+func grpcLocale(ctx context.Context) i18n.Locale {
+	return i18n.Negotiate(strings.Join(metadata.ValueFromIncomingContext(ctx, "accept-language"), ","))
+}
+
+// grpcCaller returns the subject of the verified TLS client certificate, empty for anonymous callers
+func grpcCaller(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return ""
+	}
+	return info.State.VerifiedChains[0][0].Subject.String()
+}
+
+func fromProtoRequest(req *fizzbuzzv1.FizzBuzzRequest) types.FizzBuzzRequest {
+	return types.FizzBuzzRequest{
+		Int1:         int(req.GetInt1()),
+		Int2:         int(req.GetInt2()),
+		Limit:        int(req.GetLimit()),
+		Start:        fromProtoInt(req.Start),
+		End:          fromProtoInt(req.End),
+		Step:         fromProtoInt(req.Step),
+		Str1:         req.GetStr1(),
+		Str2:         req.GetStr2(),
+		NumberFormat: req.GetNumberFormat(),
+	}
+}
+
+// fromProtoInt converts an optional field, nil when it is not set
+func fromProtoInt(i *int64) *int {
+	if i == nil {
+		return nil
+	}
+	v := int(*i)
+	return &v
+}
+
+func toProtoInt(i *int) *int64 {
+	if i == nil {
+		return nil
+	}
+	v := int64(*i)
+	return &v
+}
+
+func toProtoStats(stats types.FizzBuzzStats) *fizzbuzzv1.GetStatsResponse {
+	resp := &fizzbuzzv1.GetStatsResponse{Count: int64(stats.Count)}
+	for _, r := range stats.MostFrequentRequests {
+		resp.MostFrequentRequests = append(resp.MostFrequentRequests, toProtoRequest(r))
+	}
+	for _, variants := range stats.Variants {
+		v := &fizzbuzzv1.RequestVariants{}
+		for _, r := range variants {
+			v.Requests = append(v.Requests, toProtoRequest(r))
+		}
+		resp.Variants = append(resp.Variants, v)
+	}
+	return resp
+}
+
+func toProtoRequest(req types.FizzBuzzRequest) *fizzbuzzv1.FizzBuzzRequest {
+	return &fizzbuzzv1.FizzBuzzRequest{
+		Int1:         int64(req.Int1),
+		Int2:         int64(req.Int2),
+		Limit:        int64(req.Limit),
+		Start:        toProtoInt(req.Start),
+		End:          toProtoInt(req.End),
+		Step:         toProtoInt(req.Step),
+		Str1:         req.Str1,
+		Str2:         req.Str2,
+		NumberFormat: req.NumberFormat,
+	}
+}
@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"context"
+	fizzbuzzv1 "fizzbuzz-api/api/proto/fizzbuzz/v1"
+	"fizzbuzz-api/internal/fizzbuzzapi/controllers"
+	"fizzbuzz-api/internal/fizzbuzzapi/types"
+	"io"
+	"net"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// mockSequenceController returns the positions as values, or err when set
+type mockSequenceController struct {
+	err error
+}
+
+func (m *mockSequenceController) GenerateFizzBuzz(req types.FizzBuzzRequest) (types.FizzBuzzResponse, error) {
+	if m.err != nil {
+		return types.FizzBuzzResponse{}, m.err
+	}
+	result := make([]string, req.Limit)
+	for i := range result {
+		result[i] = strconv.Itoa(i + 1)
+	}
+	return types.FizzBuzzResponse{Result: result}, nil
+}
+
+func newGRPCTestClient(t *testing.T, generator FizzBuzzGenerator, recorder FizzBuzzStatsRecorder) fizzbuzzv1.FizzBuzzServiceClient {
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	handler := NewFizzBuzzHandler(&mockConfig, &mockLogger{}, generator, recorder)
+	fizzbuzzv1.RegisterFizzBuzzServiceServer(server, NewFizzBuzzGRPCHandler(handler, NewHealthHandler()))
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return fizzbuzzv1.NewFizzBuzzServiceClient(conn)
+}
+
+var grpcRequest = &fizzbuzzv1.FizzBuzzRequest{Int1: 3, Int2: 5, Limit: 5, Str1: "fizz", Str2: "buzz"}
+
+func Test_GRPC_Generate(t *testing.T) {
+	assert := assert.New(t)
+	client := newGRPCTestClient(t, &mockSequenceController{}, mockStatsRecorder)
+
+	resp, err := client.Generate(context.Background(), &fizzbuzzv1.GenerateRequest{Request: grpcRequest})
+	assert.NoError(err)
+	assert.Equal([]string{"1", "2", "3", "4", "5"}, resp.GetResult())
+}
+
+// mockCapturingController records the request it generates
+type mockCapturingController struct {
+	req types.FizzBuzzRequest
+}
+
+func (m *mockCapturingController) GenerateFizzBuzz(req types.FizzBuzzRequest) (types.FizzBuzzResponse, error) {
+	m.req = req
+	return types.FizzBuzzResponse{}, nil
+}
+
+func Test_GRPC_Generate_Range(t *testing.T) {
+	assert := assert.New(t)
+	generator := &mockCapturingController{}
+	client := newGRPCTestClient(t, generator, mockStatsRecorder)
+
+	start, end, step := int64(10), int64(1), int64(-3)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "accept-language", "fr")
+	_, err := client.Generate(ctx, &fizzbuzzv1.GenerateRequest{Request: &fizzbuzzv1.FizzBuzzRequest{
+		Int1: 3, Int2: 5, Start: &start, End: &end, Step: &step, Str1: "fizz", Str2: "buzz", NumberFormat: "words",
+	}})
+	assert.NoError(err)
+	assert.Equal(10, *generator.req.Start)
+	assert.Equal(1, *generator.req.End)
+	assert.Equal(-3, *generator.req.Step)
+	assert.Zero(generator.req.Limit)
+	assert.Equal("words", generator.req.NumberFormat)
+	assert.Equal("fr", generator.req.Locale)
+}
+
+func Test_GRPC_Generate_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		request   *fizzbuzzv1.FizzBuzzRequest
+		err       error
+		code      codes.Code
+		errorCode string
+	}{
+		{"missing parameters", &fizzbuzzv1.FizzBuzzRequest{Int1: 3, Limit: 5}, nil, codes.InvalidArgument, controllers.ErrCodeInvalidRequest},
+		{"invalid parameter", grpcRequest, controllers.ErrNegativeParameter, codes.InvalidArgument, controllers.ErrCodeInvalidParameter},
+		{"limit exceeded", grpcRequest, controllers.ErrLimitExceeded, codes.OutOfRange, controllers.ErrCodeLimitExceeded},
+		{"string length exceeded", grpcRequest, controllers.ErrStringLengthExceeded, codes.OutOfRange, controllers.ErrCodeStringLengthExceeded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := &mockSummaryRecorder{}
+			client := newGRPCTestClient(t, &mockSequenceController{err: tt.err}, recorder)
+
+			_, err := client.Generate(context.Background(), &fizzbuzzv1.GenerateRequest{Request: tt.request})
+			assert.Equal(t, tt.code, status.Code(err))
+			assert.Equal(t, []string{tt.errorCode}, recorder.errors)
+		})
+	}
+}
+
+func Test_GRPC_GenerateStream(t *testing.T) {
+	assert := assert.New(t)
+	client := newGRPCTestClient(t, &mockSequenceController{}, mockStatsRecorder)
+
+	stream, err := client.GenerateStream(context.Background(), &fizzbuzzv1.GenerateStreamRequest{Request: grpcRequest, ChunkSize: 2})
+	require.NoError(t, err)
+
+	var offsets []int64
+	var values []string
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		offsets = append(offsets, chunk.GetOffset())
+		values = append(values, chunk.GetValues()...)
+	}
+	assert.Equal([]int64{0, 2, 4}, offsets)
+	assert.Equal([]string{"1", "2", "3", "4", "5"}, values)
+}
+
+func Test_GRPC_GenerateStream_Error(t *testing.T) {
+	client := newGRPCTestClient(t, &mockSequenceController{err: controllers.ErrLimitExceeded}, mockStatsRecorder)
+
+	stream, err := client.GenerateStream(context.Background(), &fizzbuzzv1.GenerateStreamRequest{Request: grpcRequest})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.OutOfRange, status.Code(err))
+}
+
+var rangeStart, rangeEnd = -5, 5
+
+type mockStatsResultRecorder struct {
+	mockRecorder
+}
+
+func (m *mockStatsResultRecorder) GetStats() types.FizzBuzzStats {
+	return types.FizzBuzzStats{
+		MostFrequentRequests: []types.FizzBuzzRequest{
+			{Int1: 3, Int2: 5, Limit: 15, Str1: "fizz", Str2: "buzz"},
+			{Int1: 3, Int2: 5, Start: &rangeStart, End: &rangeEnd, Str1: "fizz", Str2: "buzz", NumberFormat: "hex"},
+		},
+		Count: 4,
+	}
+}
+
+func Test_GRPC_GetStats(t *testing.T) {
+	assert := assert.New(t)
+	client := newGRPCTestClient(t, &mockSequenceController{}, &mockStatsResultRecorder{})
+
+	resp, err := client.GetStats(context.Background(), &fizzbuzzv1.GetStatsRequest{})
+	assert.NoError(err)
+	assert.Equal(int64(4), resp.GetCount())
+	assert.Len(resp.GetMostFrequentRequests(), 2)
+	assert.Equal(int64(15), resp.GetMostFrequentRequests()[0].GetLimit())
+	assert.Nil(resp.GetMostFrequentRequests()[0].Start, "Requests with a limit have no range")
+
+	// Range requests keep their range and number format, like in /fizzbuzz/stats
+	ranged := resp.GetMostFrequentRequests()[1]
+	assert.Equal(int64(-5), ranged.GetStart())
+	assert.Equal(int64(5), ranged.GetEnd())
+	assert.Nil(ranged.Step)
+	assert.Equal("hex", ranged.GetNumberFormat())
+}
+
+func Test_GRPC_Health(t *testing.T) {
+	assert := assert.New(t)
+	client := newGRPCTestClient(t, &mockSequenceController{}, mockStatsRecorder)
+
+	resp, err := client.Health(context.Background(), &fizzbuzzv1.HealthRequest{})
+	assert.NoError(err)
+	assert.Equal(fizzbuzzv1.HealthResponse_STATUS_SERVING, resp.GetStatus())
+	assert.True(resp.GetChecks()["draining"].GetOk())
+}
@@ -1,11 +1,126 @@
 package handlers
 
 import (
+	"context"
+	"errors"
 	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
-func HealthCheck(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{"status": "healthy"})
+// Maximum time given to all readiness checks
+const readinessTimeout = 2 * time.Second
+
+var errDraining = errors.New("server is shutting down")
+
+// Checker reports whether a dependency of the server is ready to serve requests
+type Checker interface {
+	Check(ctx context.Context) error
+}
+
+type CheckerFunc func(ctx context.Context) error
+
+func (f CheckerFunc) Check(ctx context.Context) error {
+	return f(ctx)
+}
+
+type namedChecker struct {
+	name    string
+	checker Checker
+}
+
+// HealthHandler serves the liveness and readiness probes
+type HealthHandler struct {
+	draining atomic.Bool
+
+	mu       sync.RWMutex
+	checkers []namedChecker
+}
+
+func NewHealthHandler() *HealthHandler {
+	h := &HealthHandler{}
+	h.Register("draining", CheckerFunc(func(ctx context.Context) error {
+		if h.draining.Load() {
+			return errDraining
+		}
+		return nil
+	}))
+	return h
+}
+
+// Register adds a check to the readiness probe
+func (h *HealthHandler) Register(name string, checker Checker) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.checkers = append(h.checkers, namedChecker{name: name, checker: checker})
+}
+
+// SetDraining makes the readiness probe fail, so that load balancers stop routing requests before shutdown
+func (h *HealthHandler) SetDraining() {
+	h.draining.Store(true)
+}
+
+// Livez reports that the process is up, whatever the state of its dependencies
+func (h *HealthHandler) Livez(c *gin.Context) {
+	respond(c, http.StatusOK, gin.H{"status": "alive"})
+}
+
+type CheckResult struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Ready runs every registered check concurrently and reports whether they all passed, along with each result
+func (h *HealthHandler) Ready(ctx context.Context) (bool, map[string]CheckResult) {
+	h.mu.RLock()
+	checkers := h.checkers
+	h.mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(ctx, readinessTimeout)
+	defer cancel()
+
+	results := make([]CheckResult, len(checkers))
+	var wg sync.WaitGroup
+	for i, nc := range checkers {
+		wg.Go(func() {
+			results[i] = CheckResult{Status: "ok"}
+			if err := nc.checker.Check(ctx); err != nil {
+				results[i] = CheckResult{Status: "failed", Error: err.Error()}
+			}
+		})
+	}
+	wg.Wait()
+
+	ready := true
+	checks := make(map[string]CheckResult, len(checkers))
+	for i, nc := range checkers {
+		checks[nc.name] = results[i]
+		if results[i].Status != "ok" {
+			ready = false
+		}
+	}
+	return ready, checks
+}
+
+// HealthCheck is the legacy health check, it shares the state of the readiness probe and reports 503 while
+// a check fails or the server is draining
+func (h *HealthHandler) HealthCheck(c *gin.Context) {
+	if ready, _ := h.Ready(c.Request.Context()); !ready {
+		respond(c, http.StatusServiceUnavailable, gin.H{"status": "unhealthy"})
+		return
+	}
+	respond(c, http.StatusOK, gin.H{"status": "healthy"})
+}
+
+// Readyz reports 503 when any of the readiness checks fails
+func (h *HealthHandler) Readyz(c *gin.Context) {
+	ready, checks := h.Ready(c.Request.Context())
+	if !ready {
+		respond(c, http.StatusServiceUnavailable, gin.H{"status": "not ready", "checks": checks})
+		return
+	}
+	respond(c, http.StatusOK, gin.H{"status": "ready", "checks": checks})
 }
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func serveReadyz(h *HealthHandler) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/readyz", nil)
+	h.Readyz(c)
+	return w
+}
+
+func Test_Readyz(t *testing.T) {
+	assert := assert.New(t)
+	h := NewHealthHandler()
+	h.Register("stats", CheckerFunc(func(ctx context.Context) error { return nil }))
+
+	w := serveReadyz(h)
+	assert.Equal(200, w.Code)
+	assert.JSONEq(`{"status":"ready","checks":{"draining":{"status":"ok"},"stats":{"status":"ok"}}}`, w.Body.String())
+}
+
+func Test_Readyz_FailingCheck(t *testing.T) {
+	assert := assert.New(t)
+	h := NewHealthHandler()
+	h.Register("stats", CheckerFunc(func(ctx context.Context) error { return errors.New("unreachable") }))
+
+	w := serveReadyz(h)
+	assert.Equal(503, w.Code)
+	assert.JSONEq(`{"status":"not ready","checks":{"draining":{"status":"ok"},"stats":{"status":"failed","error":"unreachable"}}}`, w.Body.String())
+}
+
+func Test_Readyz_Draining(t *testing.T) {
+	assert := assert.New(t)
+	h := NewHealthHandler()
+	h.SetDraining()
+
+	w := serveReadyz(h)
+	assert.Equal(503, w.Code)
+	assert.Contains(w.Body.String(), `"draining":{"status":"failed","error":"server is shutting down"}`)
+}
+
+func Test_Livez_WhileDraining(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := NewHealthHandler()
+	h.SetDraining()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/livez", nil)
+	h.Livez(c)
+	assert.Equal(t, 200, w.Code)
+}
+
+func Test_HealthCheck(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := NewHealthHandler()
+	serve := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", "/fizzbuzz/health", nil)
+		h.HealthCheck(c)
+		return w
+	}
+
+	w := serve()
+	assert.Equal(t, 200, w.Code)
+	assert.JSONEq(t, `{"status":"healthy"}`, w.Body.String())
+
+	h.SetDraining()
+	w = serve()
+	assert.Equal(t, 503, w.Code)
+	assert.JSONEq(t, `{"status":"unhealthy"}`, w.Body.String())
+}
@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"fizzbuzz-api/internal/fizzbuzzapi/types"
+	"fmt"
+	"iter"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Optional interface for generators deducing the requests producing an observed sequence
+type FizzBuzzInferrer interface {
+	Infer(values iter.Seq[string]) (types.FizzBuzzInferResponse, error)
+}
+
+// InferFizzBuzz deduces the requests producing the sequence of the body, a JSON array of strings or one value
+// per line of text, like VerifyFizzBuzz reads them
+func (h *FizzBuzzHandler) InferFizzBuzz(c *gin.Context) {
+	inferrer, ok := h.fbGenerator.(FizzBuzzInferrer)
+	if !ok {
+		respond(c, http.StatusNotImplemented, gin.H{"code": ErrCodeNotImplemented, "error": "generator does not support inference"})
+		return
+	}
+
+	var body submission
+	values, ok := body.values(c)
+	if !ok {
+		h.RejectRequest(c, fmt.Errorf("unsupported content type %q, expected application/json or text/plain", c.ContentType()))
+		return
+	}
+
+	resp, err := inferrer.Infer(values)
+	if body.err != nil {
+		h.RejectRequest(c, body.err)
+		return
+	}
+	if err != nil {
+		h.analysisFailed(c, err)
+		return
+	}
+	respond(c, http.StatusOK, resp)
+}
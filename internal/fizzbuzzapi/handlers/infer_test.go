@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"fizzbuzz-api/internal/fizzbuzzapi/controllers"
+	"fizzbuzz-api/internal/fizzbuzzapi/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_InferFizzBuzz(t *testing.T) {
+	generator := controllers.NewFizzBuzzController(types.FizzBuzzLimits{MaxLimit: 10, MaxStringLength: 10}, &mockLogger{})
+	handler := NewFizzBuzzHandler(&mockConfig, &mockLogger{}, generator, mockStatsRecorder)
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		code        int
+		expected    string
+	}{
+		{"json", "application/json", `["1","2","fizz","4","buzz","fizz","7","8","fizz","buzz"]`, 200,
+			`"limit":10,"possible":true,"ambiguous":true,"candidates":[{"int1":3,"int2":5,"str1":"fizz","str2":"buzz","limit":10},{"int1":5,"int2":3,"str1":"buzz","str2":"fizz","limit":10}]`},
+		{"text", "text/plain", "1\n2\nfizz\n", 200,
+			`"candidates":[{"int1":3,"int2":4,"str1":"fizz","limit":3,"unbounded":["int2"]},{"int1":4,"int2":3,"str2":"fizz","limit":3,"unbounded":["int1"]},`},
+		{"rules", "application/json", `["1","a","b","a","c","ab","d"]`, 200,
+			`"possible":true,"ambiguous":false,"candidates":[],"rules":[{"divisor":2,"str":"a"},{"divisor":3,"str":"b"},{"divisor":5,"str":"c"},{"divisor":7,"str":"d"}]`},
+		{"impossible", "text/plain", "1\nfizz\n3\n4", 200,
+			`"possible":false,"ambiguous":false,"candidates":[],"conflict":{"index":4,"value":"4","expected":"fizz","reason":"unexpected_number"}`},
+		{"empty", "text/plain", "", 400, `"code":"invalid_parameter"`},
+		{"malformed", "application/json", `["1",`, 400, `"code":"invalid_request"`},
+		{"too long", "application/json", `["1","2","3","4","5","6","7","8","9","10","11"]`, 422, `"code":"limit_exceeded"`},
+		{"unsupported content type", "application/xml", `<sequence/>`, 400, `"code":"invalid_request"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, w := initMockGinUpload("/fizzbuzz/infer", tt.contentType, tt.body)
+			handler.InferFizzBuzz(c)
+			assert.Equal(t, tt.code, w.Code, w.Body.String())
+			assert.Contains(t, w.Body.String(), tt.expected)
+		})
+	}
+
+	c, w := initMockGinUpload("/fizzbuzz/infer", "application/json", `["1"]`)
+	NewFizzBuzzHandler(&mockConfig, &mockLogger{}, &mockSequenceController{}, mockStatsRecorder).InferFizzBuzz(c)
+	assert.Equal(t, 501, w.Code)
+}
@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"fizzbuzz-api/internal/fizzbuzzapi/controllers"
+	"fizzbuzz-api/internal/fizzbuzzapi/logger"
+	"fizzbuzz-api/internal/fizzbuzzapi/openapi"
+	"fizzbuzz-api/internal/fizzbuzzapi/types"
+	"fizzbuzz-api/internal/fizzbuzzapi/version"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetOpenAPI serves the OpenAPI document, with the limits in force when it is requested
+func GetOpenAPI(limits func() types.FizzBuzzLimits, log logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		doc, err := openapi.Document(limits(), version.Version)
+		if err != nil {
+			log.Error("failed to build OpenAPI document", "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"code": controllers.ErrCodeInternal, "error": "failed to build OpenAPI document"})
+			return
+		}
+		c.Data(http.StatusOK, "application/json", doc)
+	}
+}
+
+// GetDocs serves a page rendering the OpenAPI document
+func GetDocs(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", openapi.DocsPage)
+}
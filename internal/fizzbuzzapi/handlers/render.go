@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"errors"
+	fizzbuzzv1 "fizzbuzz-api/api/proto/fizzbuzz/v1"
+	"fizzbuzz-api/internal/fizzbuzzapi/encoders"
+	"fizzbuzz-api/internal/fizzbuzzapi/types"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/protobuf/proto"
+)
+
+// Response of GET /fizzbuzz/stats, typed so that it can be encoded as protobuf
+type statsResponse struct {
+	Stats types.FizzBuzzStats `json:"stats"`
+}
+
+// Encoders of the responses of every endpoint, JSON being the default
+var responseEncoders = newResponseEncoders()
+
+func newResponseEncoders() *encoders.Registry {
+	protobuf := encoders.NewProtobuf()
+	encoders.AddProtoConverter(protobuf, func(r types.FizzBuzzResponse) proto.Message {
+		return &fizzbuzzv1.GenerateResponse{Result: r.Result, DurationMs: r.Duration}
+	})
+	encoders.AddProtoConverter(protobuf, func(r statsResponse) proto.Message {
+		return toProtoStats(r.Stats)
+	})
+
+	return encoders.NewRegistry(encoders.JSON{}, encoders.Text{}, encoders.CSV{}, encoders.MessagePack{}, protobuf)
+}
+
+// respond writes v in the representation preferred by the Accept header of the request.
+// Errors without an acceptable representation are written as JSON, other responses get 406.
+func respond(c *gin.Context, status int, v any) {
+	c.Writer.Header().Add("Vary", "Accept")
+
+	enc, err := responseEncoders.Negotiate(c.GetHeader("Accept"), v)
+	if errors.Is(err, encoders.ErrNotAcceptable) {
+		if status >= http.StatusBadRequest {
+			c.JSON(status, v)
+			return
+		}
+		c.JSON(http.StatusNotAcceptable, gin.H{
+			"code":  ErrCodeNotAcceptable,
+			"error": fmt.Sprintf("no acceptable representation for %q, available: %s", c.GetHeader("Accept"), strings.Join(responseEncoders.ContentTypes(), ", ")),
+		})
+		return
+	}
+	c.Render(status, encoderRender{encoder: enc, value: v})
+}
+
+// encoderRender adapts an encoder to gin's renderers
+type encoderRender struct {
+	encoder encoders.Encoder
+	value   any
+}
+
+func (r encoderRender) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+	return r.encoder.Encode(w, r.value)
+}
+
+func (r encoderRender) WriteContentType(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", r.encoder.ContentType())
+}
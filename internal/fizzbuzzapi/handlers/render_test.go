@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	fizzbuzzv1 "fizzbuzz-api/api/proto/fizzbuzz/v1"
+	"fizzbuzz-api/internal/fizzbuzzapi/controllers"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func Test_GenerateFizzBuzz_Formats(t *testing.T) {
+	body := []byte(`{"int1":3,"int2":5,"limit":3,"str1":"fizz","str2":"buzz"}`)
+
+	tests := []struct {
+		accept      string
+		contentType string
+		body        string
+	}{
+		{"", "application/json; charset=utf-8", `{"result":["1","2","3"],"duration_ms":0}` + "\n"},
+		{"text/plain", "text/plain; charset=utf-8", "1\n2\n3\n"},
+		{"text/csv", "text/csv; charset=utf-8", "index,value\n1,1\n2,2\n3,3\n"},
+		{"application/msgpack", "application/msgpack", "\x82\xa6result\x93\xa11\xa12\xa13\xabduration_ms\x00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			c, w := initMockGinRequest(body)
+			c.Request.Header.Set("Accept", tt.accept)
+
+			handler := NewFizzBuzzHandler(&mockConfig, &mockLogger{}, &mockSequenceController{}, mockStatsRecorder)
+			handler.GenerateFizzBuzz(c)
+
+			assert.Equal(t, 200, w.Code)
+			assert.Equal(t, tt.contentType, w.Header().Get("Content-Type"))
+			assert.Equal(t, "Accept", w.Header().Get("Vary"))
+			assert.Equal(t, tt.body, w.Body.String())
+		})
+	}
+}
+
+func Test_GenerateFizzBuzz_Protobuf(t *testing.T) {
+	assert := assert.New(t)
+	c, w := initMockGinRequest([]byte(`{"int1":3,"int2":5,"limit":3,"str1":"fizz","str2":"buzz"}`))
+	c.Request.Header.Set("Accept", "application/x-protobuf")
+
+	handler := NewFizzBuzzHandler(&mockConfig, &mockLogger{}, &mockSequenceController{}, mockStatsRecorder)
+	handler.GenerateFizzBuzz(c)
+
+	assert.Equal(200, w.Code)
+	assert.Equal("application/x-protobuf", w.Header().Get("Content-Type"))
+	resp := &fizzbuzzv1.GenerateResponse{}
+	assert.NoError(proto.Unmarshal(w.Body.Bytes(), resp))
+	assert.Equal([]string{"1", "2", "3"}, resp.Result)
+}
+
+func Test_GenerateFizzBuzz_NotAcceptable(t *testing.T) {
+	assert := assert.New(t)
+	c, w := initMockGinRequest([]byte(`{"int1":3,"int2":5,"limit":3,"str1":"fizz","str2":"buzz"}`))
+	c.Request.Header.Set("Accept", "image/png")
+
+	handler := NewFizzBuzzHandler(&mockConfig, &mockLogger{}, &mockSequenceController{}, mockStatsRecorder)
+	handler.GenerateFizzBuzz(c)
+
+	assert.Equal(406, w.Code)
+	assert.Contains(w.Body.String(), `"code":"not_acceptable"`)
+	assert.Contains(w.Body.String(), "text/csv")
+}
+
+func Test_GenerateFizzBuzz_ErrorsAsJSON(t *testing.T) {
+	assert := assert.New(t)
+	c, w := initMockGinRequest([]byte(`{"int1":3,"int2":5,"limit":3,"str1":"fizz","str2":"buzz"}`))
+	c.Request.Header.Set("Accept", "text/csv")
+
+	handler := NewFizzBuzzHandler(&mockConfig, &mockLogger{}, &mockSequenceController{err: controllers.ErrLimitExceeded}, mockStatsRecorder)
+	handler.GenerateFizzBuzz(c)
+
+	assert.Equal(422, w.Code)
+	assert.Equal("application/json; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.JSONEq(`{"code":"limit_exceeded","error":"limit exceeds maximum allowed"}`, w.Body.String())
+}
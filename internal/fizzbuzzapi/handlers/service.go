@@ -1,16 +1,26 @@
 package handlers
 
 import (
+	"bufio"
+	"encoding/json"
 	"errors"
 	"fizzbuzz-api/internal/fizzbuzzapi/config"
 	"fizzbuzz-api/internal/fizzbuzzapi/controllers"
 	"fizzbuzz-api/internal/fizzbuzzapi/logger"
+	"fizzbuzz-api/internal/fizzbuzzapi/middleware"
 	"fizzbuzz-api/internal/fizzbuzzapi/types"
+	"iter"
 	"net/http"
+	"slices"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+// Size of the buffer of streamed responses, flushed when half full
+const streamBufferSize = 32 << 10
+
 type FizzBuzzHandler struct {
 	cfg           *config.Config
 	fbGenerator   FizzBuzzGenerator
@@ -29,6 +39,34 @@ type FizzBuzzStatsRecorder interface {
 	SaveStat(req types.FizzBuzzRequest) error
 }
 
+// Optional interface for generators producing values as they are consumed, used to stream large sequences
+type FizzBuzzStreamer interface {
+	StreamFizzBuzz(req types.FizzBuzzRequest) (iter.Seq[string], error)
+}
+
+// Optional interface for generators describing sequences by their period, see types.FizzBuzzCompactResponse
+type FizzBuzzCompactor interface {
+	GenerateCompact(req types.FizzBuzzRequest) (types.FizzBuzzCompactResponse, error)
+}
+
+// Optional interface for generators telling which rules produced each value, see types.FizzBuzzExplainedValue
+type FizzBuzzExplainer interface {
+	ExplainFizzBuzz(req types.FizzBuzzRequest) (types.FizzBuzzExplainResponse, error)
+	StreamExplained(req types.FizzBuzzRequest) (iter.Seq[types.FizzBuzzExplainedValue], *types.FizzBuzzExplainSummary, error)
+}
+
+// Optional interface for stats recorders tracking failed requests and aggregated metrics
+type FizzBuzzStatsSummarizer interface {
+	SaveError(code string)
+	GetSummary() types.FizzBuzzStatsSummary
+}
+
+// Optional interface for stats recorders supporting administration (reset and retention)
+type FizzBuzzStatsAdministrator interface {
+	ResetStats(filter types.FizzBuzzStatsFilter) int
+	PruneStats(before time.Time) int
+}
+
 func NewFizzBuzzHandler(cfg *config.Config, log logger.Logger, generator FizzBuzzGenerator, statsRecorder FizzBuzzStatsRecorder) *FizzBuzzHandler {
 	return &FizzBuzzHandler{
 		cfg:           cfg,
@@ -42,36 +80,228 @@ func (h *FizzBuzzHandler) GenerateFizzBuzz(c *gin.Context) {
 	var req types.FizzBuzzRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		h.log.Error("failed to bind JSON", "error", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		h.RejectRequest(c, err)
 		return
 	}
-	h.log.Info("received FizzBuzz request", "request", req)
+	req.Locale = string(middleware.Locale(c))
+	h.log.Info("received FizzBuzz request", "request", req, "caller", middleware.Caller(c))
 
-	result, err := h.fbGenerator.GenerateFizzBuzz(req)
-	if err != nil {
-		h.log.Error("failed to generate FizzBuzz", "error", err)
-		if errors.Is(controllers.ErrLimitExceeded, err) || errors.Is(controllers.ErrStringLengthExceeded, err) {
-			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	explainer, ok := h.explainer(c)
+	if !ok {
+		return
+	}
+
+	var (
+		result any
+		err    error
+	)
+	switch format := c.Query("format"); format {
+	case "", "full":
+		if explainer != nil {
+			result, err = explainer.ExplainFizzBuzz(req)
 		} else {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			result, err = h.fbGenerator.GenerateFizzBuzz(req)
+		}
+	case "compact":
+		if explainer != nil {
+			h.saveError(controllers.ErrCodeInvalidParameter)
+			respond(c, http.StatusBadRequest, gin.H{"code": controllers.ErrCodeInvalidParameter, "error": "the compact format cannot be explained"})
+			return
+		}
+		compactor, ok := h.fbGenerator.(FizzBuzzCompactor)
+		if !ok {
+			respond(c, http.StatusNotImplemented, gin.H{"code": ErrCodeNotImplemented, "error": "generator does not support the compact format"})
+			return
+		}
+		result, err = compactor.GenerateCompact(req)
+	default:
+		h.saveError(controllers.ErrCodeInvalidParameter)
+		respond(c, http.StatusBadRequest, gin.H{"code": controllers.ErrCodeInvalidParameter, "error": "unknown format " + strconv.Quote(format) + ", expected full or compact"})
+		return
+	}
+	if err != nil {
+		h.generationFailed(c, err)
+		return
+	}
+
+	h.saveStat(req)
+
+	respond(c, http.StatusOK, result)
+}
+
+// StreamFizzBuzz writes the sequence as newline-delimited JSON strings, flushing as values are generated.
+// With explain=true, values are written as objects along with the rules they matched, followed by a last
+// line holding the summary of the sequence.
+// Errors found before streaming starts are reported like GenerateFizzBuzz does.
+func (h *FizzBuzzHandler) StreamFizzBuzz(c *gin.Context) {
+	var req types.FizzBuzzRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.RejectRequest(c, err)
+		return
+	}
+	req.Locale = string(middleware.Locale(c))
+	h.log.Info("received FizzBuzz stream request", "request", req, "caller", middleware.Caller(c))
+
+	explainer, ok := h.explainer(c)
+	if !ok {
+		return
+	}
+	if explainer != nil {
+		seq, summary, err := explainer.StreamExplained(req)
+		if err != nil {
+			h.generationFailed(c, err)
+			return
+		}
+		h.saveStat(req)
+		if writeStream(h, c, seq) {
+			writeStream(h, c, slices.Values([]gin.H{{"summary": summary}}))
 		}
 		return
 	}
 
-	err = h.statsRecorder.SaveStat(req)
+	seq, err := h.sequence(req)
 	if err != nil {
-		h.log.Error("failed to save stats", "error", err)
-		// Proceed without failing the request
+		h.generationFailed(c, err)
+		return
+	}
+	h.saveStat(req)
+	writeStream(h, c, seq)
+}
+
+// writeStream writes values as newline-delimited JSON, flushing as they are generated, and reports whether
+// all of them were written. The first call sends the headers of the stream.
+func writeStream[T any](h *FizzBuzzHandler, c *gin.Context, seq iter.Seq[T]) bool {
+	if !c.Writer.Written() {
+		c.Header("Content-Type", "application/x-ndjson")
+		c.Status(http.StatusOK)
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"result":      result.Result,
-		"duration_ms": result.Duration,
-	})
+	// The write timeout applies to each flush rather than to the whole stream
+	rc := http.NewResponseController(c.Writer)
+	w := bufio.NewWriterSize(c.Writer, streamBufferSize)
+	enc := json.NewEncoder(w)
+	written := 0
+	for value := range seq {
+		if err := enc.Encode(value); err != nil {
+			h.log.Error("failed to stream FizzBuzz", "error", err, "written", written)
+			return false
+		}
+		written++
+		if w.Buffered() >= streamBufferSize/2 {
+			if h.cfg.WriteTimeout > 0 {
+				rc.SetWriteDeadline(time.Now().Add(h.cfg.WriteTimeout))
+			}
+			if err := w.Flush(); err != nil {
+				h.log.Error("failed to stream FizzBuzz", "error", err, "written", written)
+				return false
+			}
+			c.Writer.Flush()
+		}
+	}
+	if err := w.Flush(); err != nil {
+		h.log.Error("failed to stream FizzBuzz", "error", err, "written", written)
+		return false
+	}
+	return true
+}
+
+// generationFailed reports an error of the controller found before any value was written
+func (h *FizzBuzzHandler) generationFailed(c *gin.Context, err error) {
+	h.log.Error("failed to generate FizzBuzz", "error", err)
+	code := controllers.ErrorCode(err)
+	h.saveError(code)
+	respond(c, statusOf(code).http, errorBody(c, code, err))
+}
+
+// explainer returns the generator explaining values when the request asks for it with explain=true, nil otherwise.
+// It responds and returns false when the parameter is invalid or the generator cannot explain values.
+func (h *FizzBuzzHandler) explainer(c *gin.Context) (FizzBuzzExplainer, bool) {
+	explain, err := strconv.ParseBool(c.DefaultQuery("explain", "false"))
+	if err != nil {
+		h.saveError(controllers.ErrCodeInvalidParameter)
+		respond(c, http.StatusBadRequest, gin.H{"code": controllers.ErrCodeInvalidParameter, "error": "explain must be true or false"})
+		return nil, false
+	}
+	if !explain {
+		return nil, true
+	}
+	explainer, ok := h.fbGenerator.(FizzBuzzExplainer)
+	if !ok {
+		respond(c, http.StatusNotImplemented, gin.H{"code": ErrCodeNotImplemented, "error": "generator does not support explanations"})
+		return nil, false
+	}
+	return explainer, true
 }
 
 func (h *FizzBuzzHandler) GetFizzBuzzStats(c *gin.Context) {
 	stats := h.statsRecorder.GetStats()
-	c.JSON(http.StatusOK, gin.H{"stats": stats})
+	respond(c, http.StatusOK, statsResponse{Stats: stats})
+}
+
+func (h *FizzBuzzHandler) GetFizzBuzzStatsSummary(c *gin.Context) {
+	summarizer, ok := h.statsRecorder.(FizzBuzzStatsSummarizer)
+	if !ok {
+		respond(c, http.StatusNotImplemented, gin.H{"code": ErrCodeNotImplemented, "error": "stats storage does not support summaries"})
+		return
+	}
+	respond(c, http.StatusOK, gin.H{"summary": summarizer.GetSummary()})
+}
+
+func (h *FizzBuzzHandler) ResetFizzBuzzStats(c *gin.Context) {
+	admin, ok := h.statsRecorder.(FizzBuzzStatsAdministrator)
+	if !ok {
+		respond(c, http.StatusNotImplemented, gin.H{"code": ErrCodeNotImplemented, "error": "stats storage does not support reset"})
+		return
+	}
+
+	var filter types.FizzBuzzStatsFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		h.log.Error("failed to bind stats filter", "error", err)
+		respond(c, http.StatusBadRequest, gin.H{"code": controllers.ErrCodeInvalidRequest, "error": err.Error()})
+		return
+	}
+
+	removed := admin.ResetStats(filter)
+	respond(c, http.StatusOK, gin.H{"removed": removed})
+}
+
+// RejectRequest responds 400 to a malformed request, or 413 when its body exceeds the allowed size,
+// and records it as invalid in stats
+func (h *FizzBuzzHandler) RejectRequest(c *gin.Context, err error) {
+	h.log.Error("invalid request", "error", err, "path", c.FullPath())
+	h.saveError(controllers.ErrCodeInvalidRequest)
+	status := http.StatusBadRequest
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		status = http.StatusRequestEntityTooLarge
+	}
+	respond(c, status, gin.H{"code": controllers.ErrCodeInvalidRequest, "error": err.Error()})
+	c.Abort()
+}
+
+// sequence returns the values of the sequence, lazily when the generator supports it
+func (h *FizzBuzzHandler) sequence(req types.FizzBuzzRequest) (iter.Seq[string], error) {
+	if streamer, ok := h.fbGenerator.(FizzBuzzStreamer); ok {
+		return streamer.StreamFizzBuzz(req)
+	}
+	result, err := h.fbGenerator.GenerateFizzBuzz(req)
+	if err != nil {
+		return nil, err
+	}
+	return slices.Values(result.Result), nil
+}
+
+func (h *FizzBuzzHandler) saveStat(req types.FizzBuzzRequest) {
+	if err := h.statsRecorder.SaveStat(req); err != nil {
+		h.log.Error("failed to save stats", "error", err)
+		// Proceed without failing the request
+	}
+}
+
+// saveError records a failed request when the stats recorder supports it
+func (h *FizzBuzzHandler) saveError(code string) {
+	if summarizer, ok := h.statsRecorder.(FizzBuzzStatsSummarizer); ok {
+		summarizer.SaveError(code)
+	}
 }
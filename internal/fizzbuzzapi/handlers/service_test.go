@@ -2,10 +2,13 @@ package handlers
 
 import (
 	"bytes"
+	"errors"
 	"fizzbuzz-api/internal/fizzbuzzapi/config"
+	"fizzbuzz-api/internal/fizzbuzzapi/controllers"
 	"fizzbuzz-api/internal/fizzbuzzapi/types"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -87,3 +90,207 @@ func Test_GenerateFizzBuzz_MissingRequestParams(t *testing.T) {
 	handler.GenerateFizzBuzz(c)
 	assert.Equal(400, w.Code)
 }
+
+type mockAdminRecorder struct {
+	mockRecorder
+	filter types.FizzBuzzStatsFilter
+}
+
+func (m *mockAdminRecorder) ResetStats(filter types.FizzBuzzStatsFilter) int {
+	m.filter = filter
+	return 3
+}
+func (m *mockAdminRecorder) PruneStats(before time.Time) int {
+	return 0
+}
+
+func Test_ResetFizzBuzzStats(t *testing.T) {
+	assert := assert.New(t)
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("DELETE", "/fizzbuzz/stats?int1=3&str2=buzz", nil)
+
+	recorder := &mockAdminRecorder{}
+	handler := NewFizzBuzzHandler(&mockConfig, &mockLogger{}, mockFizzBuzzController, recorder)
+	handler.ResetFizzBuzzStats(c)
+
+	assert.Equal(200, w.Code)
+	assert.JSONEq(`{"removed":3}`, w.Body.String())
+	assert.Equal(3, *recorder.filter.Int1)
+	assert.Equal("buzz", *recorder.filter.Str2)
+	assert.Nil(recorder.filter.Int2)
+}
+
+func Test_ResetFizzBuzzStats_NotSupported(t *testing.T) {
+	assert := assert.New(t)
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("DELETE", "/fizzbuzz/stats", nil)
+
+	handler := NewFizzBuzzHandler(&mockConfig, &mockLogger{}, mockFizzBuzzController, mockStatsRecorder)
+	handler.ResetFizzBuzzStats(c)
+	assert.Equal(501, w.Code)
+}
+
+type mockSummaryRecorder struct {
+	mockRecorder
+	errors []string
+}
+
+func (m *mockSummaryRecorder) SaveError(code string) {
+	m.errors = append(m.errors, code)
+}
+func (m *mockSummaryRecorder) GetSummary() types.FizzBuzzStatsSummary {
+	return types.FizzBuzzStatsSummary{TotalRequests: 7}
+}
+
+func Test_GenerateFizzBuzz_RecordsErrors(t *testing.T) {
+	assert := assert.New(t)
+	body := []byte(`{"int1":"invalid","int2":2,"limit":2,"str1":"a","str2":"b"}`)
+	c, w := initMockGinRequest(body)
+
+	recorder := &mockSummaryRecorder{}
+	handler := NewFizzBuzzHandler(&mockConfig, &mockLogger{}, mockFizzBuzzController, recorder)
+	handler.GenerateFizzBuzz(c)
+
+	assert.Equal(400, w.Code)
+	assert.Equal([]string{"invalid_request"}, recorder.errors)
+}
+
+func Test_GetFizzBuzzStatsSummary(t *testing.T) {
+	assert := assert.New(t)
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/fizzbuzz/stats/summary", nil)
+
+	handler := NewFizzBuzzHandler(&mockConfig, &mockLogger{}, mockFizzBuzzController, &mockSummaryRecorder{})
+	handler.GetFizzBuzzStatsSummary(c)
+
+	assert.Equal(200, w.Code)
+	assert.Contains(w.Body.String(), `"total_requests":7`)
+}
+
+func Test_StreamFizzBuzz(t *testing.T) {
+	assert := assert.New(t)
+	body := []byte(`{"int1":3,"int2":5,"limit":4,"str1":"fizz","str2":"buzz"}`)
+	c, w := initMockGinRequest(body)
+
+	handler := NewFizzBuzzHandler(&mockConfig, &mockLogger{}, &mockSequenceController{}, mockStatsRecorder)
+	handler.StreamFizzBuzz(c)
+
+	assert.Equal(200, w.Code)
+	assert.Equal("application/x-ndjson", w.Header().Get("Content-Type"))
+	assert.Equal("\"1\"\n\"2\"\n\"3\"\n\"4\"\n", w.Body.String())
+}
+
+func Test_StreamFizzBuzz_Error(t *testing.T) {
+	assert := assert.New(t)
+	body := []byte(`{"int1":3,"int2":5,"limit":4,"str1":"fizz","str2":"buzz"}`)
+	c, w := initMockGinRequest(body)
+
+	handler := NewFizzBuzzHandler(&mockConfig, &mockLogger{}, &mockSequenceController{err: controllers.ErrLimitExceeded}, mockStatsRecorder)
+	handler.StreamFizzBuzz(c)
+
+	assert.Equal(422, w.Code)
+	assert.JSONEq(`{"code":"limit_exceeded","error":"limit exceeds maximum allowed"}`, w.Body.String())
+}
+
+func Test_GenerateFizzBuzz_UnclassifiedError(t *testing.T) {
+	assert := assert.New(t)
+	body := []byte(`{"int1":3,"int2":5,"limit":4,"str1":"fizz","str2":"buzz"}`)
+	c, w := initMockGinRequest(body)
+
+	handler := NewFizzBuzzHandler(&mockConfig, &mockLogger{}, &mockSequenceController{err: errors.New("unexpected")}, mockStatsRecorder)
+	handler.GenerateFizzBuzz(c)
+
+	assert.Equal(400, w.Code)
+	assert.JSONEq(`{"code":"internal_error","error":"unexpected"}`, w.Body.String())
+}
+
+func Test_StreamFizzBuzz_LocalizedError(t *testing.T) {
+	assert := assert.New(t)
+	body := []byte(`{"int1":3,"int2":5,"limit":4,"str1":"fizz","str2":"buzz"}`)
+	c, w := initMockGinRequest(body)
+	c.Request.Header.Set("Accept-Language", "fr-FR,fr;q=0.9,en;q=0.8")
+
+	handler := NewFizzBuzzHandler(&mockConfig, &mockLogger{}, &mockSequenceController{err: controllers.ErrLimitExceeded}, mockStatsRecorder)
+	handler.StreamFizzBuzz(c)
+
+	assert.Equal(422, w.Code)
+	assert.JSONEq(`{"code":"limit_exceeded","error":"la limite dépasse le maximum autorisé"}`, w.Body.String())
+}
+
+func Test_GenerateFizzBuzz_Compact(t *testing.T) {
+	assert := assert.New(t)
+	body := []byte(`{"int1":3,"int2":5,"limit":100,"str1":"fizz","str2":"buzz"}`)
+	generator := controllers.NewFizzBuzzController(types.FizzBuzzLimits{MaxLimit: 100, MaxStringLength: 10}, &mockLogger{})
+	handler := NewFizzBuzzHandler(&mockConfig, &mockLogger{}, generator, mockStatsRecorder)
+
+	c, w := initMockGinRequest(body)
+	c.Request.URL.RawQuery = "format=compact"
+	handler.GenerateFizzBuzz(c)
+	assert.Equal(200, w.Code)
+	assert.Contains(w.Body.String(), `"limit":100,"start":1,"step":1,"period":15,"pattern":"..1.21..12.1..3","str1":"fizz","str2":"buzz"`)
+
+	c, w = initMockGinRequest(body)
+	c.Request.URL.RawQuery = "format=compact"
+	NewFizzBuzzHandler(&mockConfig, &mockLogger{}, &mockSequenceController{}, mockStatsRecorder).GenerateFizzBuzz(c)
+	assert.Equal(501, w.Code)
+
+	c, w = initMockGinRequest(body)
+	c.Request.URL.RawQuery = "format=sparse"
+	handler.GenerateFizzBuzz(c)
+	assert.Equal(400, w.Code)
+	assert.Contains(w.Body.String(), `"code":"invalid_parameter"`)
+}
+
+func Test_GenerateFizzBuzz_Explain(t *testing.T) {
+	assert := assert.New(t)
+	body := []byte(`{"int1":2,"int2":3,"limit":6,"str1":"fizz","str2":"buzz"}`)
+	generator := controllers.NewFizzBuzzController(types.FizzBuzzLimits{MaxLimit: 100, MaxStringLength: 10}, &mockLogger{})
+	handler := NewFizzBuzzHandler(&mockConfig, &mockLogger{}, generator, mockStatsRecorder)
+
+	c, w := initMockGinRequest(body)
+	c.Request.URL.RawQuery = "explain=true"
+	handler.GenerateFizzBuzz(c)
+	assert.Equal(200, w.Code)
+	assert.Contains(w.Body.String(), `{"index":6,"number":6,"rules":["int1","int2"],"token":"str1str2","value":"fizzbuzz"}`)
+	assert.Contains(w.Body.String(), `"summary":{"period":6,"counts":{"number":2,"str1":2,"str1str2":1,"str2":1},"first_occurrences":{"number":1,"str1":2,"str1str2":6,"str2":3}}`)
+
+	c, w = initMockGinRequest(body)
+	c.Request.URL.RawQuery = "explain=true&format=compact"
+	handler.GenerateFizzBuzz(c)
+	assert.Equal(400, w.Code)
+	assert.Contains(w.Body.String(), `"code":"invalid_parameter"`)
+
+	c, w = initMockGinRequest(body)
+	c.Request.URL.RawQuery = "explain=maybe"
+	handler.GenerateFizzBuzz(c)
+	assert.Equal(400, w.Code)
+
+	c, w = initMockGinRequest(body)
+	c.Request.URL.RawQuery = "explain=true"
+	NewFizzBuzzHandler(&mockConfig, &mockLogger{}, &mockSequenceController{}, mockStatsRecorder).GenerateFizzBuzz(c)
+	assert.Equal(501, w.Code)
+}
+
+func Test_StreamFizzBuzz_Explain(t *testing.T) {
+	assert := assert.New(t)
+	body := []byte(`{"int1":2,"int2":3,"limit":3,"str1":"fizz","str2":"buzz"}`)
+	c, w := initMockGinRequest(body)
+	c.Request.URL.RawQuery = "explain=true"
+
+	generator := controllers.NewFizzBuzzController(types.FizzBuzzLimits{MaxLimit: 100, MaxStringLength: 10}, &mockLogger{})
+	NewFizzBuzzHandler(&mockConfig, &mockLogger{}, generator, mockStatsRecorder).StreamFizzBuzz(c)
+
+	assert.Equal(200, w.Code)
+	assert.Equal("application/x-ndjson", w.Header().Get("Content-Type"))
+	assert.Equal(`{"index":1,"number":1,"rules":[],"token":"number","value":"1"}
+{"index":2,"number":2,"rules":["int1"],"token":"str1","value":"fizz"}
+{"index":3,"number":3,"rules":["int2"],"token":"str2","value":"buzz"}
+{"summary":{"period":3,"counts":{"number":1,"str1":1,"str1str2":0,"str2":1},"first_occurrences":{"number":1,"str1":2,"str2":3}}}
+`, w.Body.String())
+}
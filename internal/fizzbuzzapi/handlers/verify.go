@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fizzbuzz-api/internal/fizzbuzzapi/middleware"
+	"fizzbuzz-api/internal/fizzbuzzapi/types"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Optional interface for generators grading submitted sequences
+type FizzBuzzVerifier interface {
+	Verify(req types.FizzBuzzRequest, submitted iter.Seq[string]) (types.FizzBuzzVerifyResponse, error)
+}
+
+// VerifyFizzBuzz grades the sequence of the body, a JSON array of strings or one value per line of text,
+// against the sequence of the query parameters. Values are compared as they are read, the body being left
+// unread by request validation.
+func (h *FizzBuzzHandler) VerifyFizzBuzz(c *gin.Context) {
+	verifier, ok := h.fbGenerator.(FizzBuzzVerifier)
+	if !ok {
+		respond(c, http.StatusNotImplemented, gin.H{"code": ErrCodeNotImplemented, "error": "generator does not support verification"})
+		return
+	}
+
+	var req types.FizzBuzzRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.RejectRequest(c, err)
+		return
+	}
+	req.Locale = string(middleware.Locale(c))
+
+	var body submission
+	submitted, ok := body.values(c)
+	if !ok {
+		h.RejectRequest(c, fmt.Errorf("unsupported content type %q, expected application/json or text/plain", c.ContentType()))
+		return
+	}
+
+	resp, err := verifier.Verify(req, submitted)
+	if err != nil {
+		h.analysisFailed(c, err)
+		return
+	}
+	if body.err != nil {
+		h.RejectRequest(c, body.err)
+		return
+	}
+	respond(c, http.StatusOK, resp)
+}
+
+// submission reads the values of a submitted sequence as they are consumed, and keeps the error ending them
+type submission struct {
+	err error
+}
+
+// values returns the values of the request body, a JSON array of strings or one value per line of text,
+// and false for other content types
+func (s *submission) values(c *gin.Context) (iter.Seq[string], bool) {
+	switch c.ContentType() {
+	case "application/json":
+		return s.jsonArray(c.Request.Body), true
+	case "text/plain":
+		return s.lines(c.Request.Body), true
+	default:
+		return nil, false
+	}
+}
+
+// lines returns the lines of r, without their line feed nor carriage return
+func (s *submission) lines(r io.Reader) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			if !yield(scanner.Text()) {
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			s.err = fmt.Errorf("invalid request body: %w", err)
+		}
+	}
+}
+
+// jsonArray returns the strings of the JSON array of r, decoded one at a time
+func (s *submission) jsonArray(r io.Reader) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		dec := json.NewDecoder(r)
+		tok, err := dec.Token()
+		if err != nil {
+			s.err = fmt.Errorf("invalid request body: %w", err)
+			return
+		}
+		if tok != json.Delim('[') {
+			s.err = errors.New("invalid request body: expected a JSON array of strings")
+			return
+		}
+		for dec.More() {
+			var value string
+			if err := dec.Decode(&value); err != nil {
+				s.err = fmt.Errorf("invalid request body: %w", err)
+				return
+			}
+			if !yield(value) {
+				return
+			}
+		}
+		if _, err := dec.Token(); err != nil {
+			s.err = fmt.Errorf("invalid request body: %w", err)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"fizzbuzz-api/internal/fizzbuzzapi/controllers"
+	"fizzbuzz-api/internal/fizzbuzzapi/types"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func initMockGinUpload(target, contentType, body string) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", target, strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", contentType)
+	return c, w
+}
+
+func Test_VerifyFizzBuzz(t *testing.T) {
+	generator := controllers.NewFizzBuzzController(types.FizzBuzzLimits{MaxLimit: 100, MaxStringLength: 10}, &mockLogger{})
+	handler := NewFizzBuzzHandler(&mockConfig, &mockLogger{}, generator, mockStatsRecorder)
+	const target = "/fizzbuzz/verify?int1=3&int2=5&limit=5&str1=fizz&str2=buzz"
+
+	tests := []struct {
+		name        string
+		target      string
+		contentType string
+		body        string
+		code        int
+		expected    string
+	}{
+		{"json pass", target, "application/json", `["1","2","fizz","4","buzz"]`, 200, `"pass":true,"expected":5,"submitted":5,"mismatches":0,"diff"`},
+		{"text pass", target, "text/plain", "1\r\n2\r\nfizz\r\n4\r\nbuzz\r\n", 200, `"pass":true`},
+		{"text mismatch", target, "text/plain; charset=utf-8", "1\n2\nbuzz\n4", 200,
+			`"pass":false,"expected":5,"submitted":4,"mismatches":2,"first_mismatch":{"index":3,"expected":"fizz","actual":"buzz"},"diff":{"changed":1,"missing":1,"extra":0,"by_token":{"str1":1,"str2":1},"hunks":[{"start":3,"end":3},{"start":5,"end":5}]}`},
+		{"range", "/fizzbuzz/verify?int1=3&int2=5&start=3&end=1&str1=fizz&str2=buzz", "application/json", `["fizz","2","1"]`, 200, `"pass":true`},
+		{"malformed json", target, "application/json", `["1","2",3]`, 400, `"code":"invalid_request"`},
+		{"not an array", target, "application/json", `{"sequence":[]}`, 400, `"code":"invalid_request"`},
+		{"unsupported content type", target, "application/xml", `<sequence/>`, 400, `"code":"invalid_request"`},
+		{"missing parameter", "/fizzbuzz/verify?int1=3&int2=5&str1=fizz&str2=buzz", "application/json", `[]`, 400, `"code":"invalid_request"`},
+		{"limit exceeded", "/fizzbuzz/verify?int1=3&int2=5&limit=101&str1=fizz&str2=buzz", "application/json", `[]`, 422, `"code":"limit_exceeded"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, w := initMockGinUpload(tt.target, tt.contentType, tt.body)
+			handler.VerifyFizzBuzz(c)
+			assert.Equal(t, tt.code, w.Code, w.Body.String())
+			assert.Contains(t, w.Body.String(), tt.expected)
+		})
+	}
+
+	c, w := initMockGinUpload(target, "application/json", `[]`)
+	NewFizzBuzzHandler(&mockConfig, &mockLogger{}, &mockSequenceController{}, mockStatsRecorder).VerifyFizzBuzz(c)
+	assert.Equal(t, 501, w.Code)
+}
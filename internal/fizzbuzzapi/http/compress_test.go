@@ -0,0 +1,165 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const largeRequest = `{"int1":3,"int2":5,"limit":10000,"str1":"fizz","str2":"buzz"}`
+
+func newCompressionTestRouter(t *testing.T) *httptest.Server {
+	cfg := testConfig
+	cfg.MaxFizzBuzzLimit = 10000
+	server := httptest.NewServer(newTestRouterWith(t, cfg))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func decode(t *testing.T, encoding string, body io.Reader) []byte {
+	var r io.Reader
+	switch encoding {
+	case "gzip":
+		gr, err := gzip.NewReader(body)
+		require.NoError(t, err)
+		r = gr
+	case "zstd":
+		zr, err := zstd.NewReader(body)
+		require.NoError(t, err)
+		defer zr.Close()
+		r = zr
+	default:
+		r = body
+	}
+	b, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return b
+}
+
+func Test_Compress(t *testing.T) {
+	server := newCompressionTestRouter(t)
+
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		body           string
+		encoding       string
+	}{
+		{"gzip", "gzip", largeRequest, "gzip"},
+		{"zstd preferred on ties", "gzip, zstd", largeRequest, "zstd"},
+		{"q-values", "zstd;q=0.5, gzip", largeRequest, "gzip"},
+		{"wildcard", "*", largeRequest, "zstd"},
+		{"excluded", "gzip;q=0, zstd;q=0", largeRequest, ""},
+		{"unsupported", "br", largeRequest, ""},
+		{"below minimum size", "gzip", `{"int1":3,"int2":5,"limit":15,"str1":"fizz","str2":"buzz"}`, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			req := httptest.NewRequest("POST", server.URL+"/fizzbuzz/generate", strings.NewReader(tt.body))
+			req.RequestURI = ""
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+
+			resp, err := server.Client().Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(200, resp.StatusCode)
+			assert.Equal(tt.encoding, resp.Header.Get("Content-Encoding"))
+			assert.Contains(resp.Header.Values("Vary"), "Accept-Encoding")
+
+			var result struct {
+				Result []string `json:"result"`
+			}
+			assert.NoError(json.Unmarshal(decode(t, tt.encoding, resp.Body), &result))
+			assert.Equal("fizzbuzz", result.Result[14])
+		})
+	}
+}
+
+func Test_Compress_Stream(t *testing.T) {
+	assert := assert.New(t)
+	server := newCompressionTestRouter(t)
+
+	req := httptest.NewRequest("POST", server.URL+"/fizzbuzz/generate/stream", strings.NewReader(largeRequest))
+	req.RequestURI = ""
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept-Encoding", "zstd")
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal("zstd", resp.Header.Get("Content-Encoding"))
+	lines := strings.Split(strings.TrimSpace(string(decode(t, "zstd", resp.Body))), "\n")
+	assert.Len(lines, 10000)
+	assert.Equal(`"fizzbuzz"`, lines[14])
+}
+
+func Test_Compress_ErrorsNotBuffered(t *testing.T) {
+	assert := assert.New(t)
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/fizzbuzz/generate", strings.NewReader(`{"int1":3,"int2":5,"limit":100000,"str1":"fizz","str2":"buzz"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept-Encoding", "gzip")
+	newTestRouter(t).ServeHTTP(w, req)
+
+	assert.Equal(422, w.Code)
+	assert.Empty(w.Header().Get("Content-Encoding"))
+	assert.Contains(w.Body.String(), `"code":"limit_exceeded"`)
+}
+
+func Test_Decompress(t *testing.T) {
+	var gzipped bytes.Buffer
+	gw := gzip.NewWriter(&gzipped)
+	gw.Write([]byte(largeRequest))
+	gw.Close()
+
+	zw, _ := zstd.NewWriter(nil)
+	zstded := zw.EncodeAll([]byte(largeRequest), nil)
+
+	// Highly compressible body decoding to more than the allowed size
+	var bomb bytes.Buffer
+	gw = gzip.NewWriter(&bomb)
+	gw.Write([]byte(`{"int1":3,"int2":5,"limit":15,"str1":"fizz","str2":"buzz"` + strings.Repeat(" ", 2<<20) + `}`))
+	gw.Close()
+
+	tests := []struct {
+		name     string
+		encoding string
+		body     []byte
+		status   int
+		code     string
+	}{
+		{"gzip", "gzip", gzipped.Bytes(), 200, ""},
+		{"zstd", "zstd", zstded, 200, ""},
+		{"identity", "identity", []byte(largeRequest), 200, ""},
+		{"invalid body", "gzip", []byte(largeRequest), 400, "invalid_request"},
+		{"unsupported encoding", "br", []byte(largeRequest), 415, "unsupported_encoding"},
+		{"too large once decoded", "gzip", bomb.Bytes(), 413, "invalid_request"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testConfig
+			cfg.MaxFizzBuzzLimit = 10000
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/fizzbuzz/generate", bytes.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Content-Encoding", tt.encoding)
+			newTestRouterWith(t, cfg).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.status, w.Code, w.Body.String())
+			if tt.code != "" {
+				assert.Contains(t, w.Body.String(), `"code":"`+tt.code+`"`)
+			}
+		})
+	}
+}
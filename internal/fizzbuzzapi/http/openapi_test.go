@@ -0,0 +1,318 @@
+package http
+
+import (
+	"encoding/json"
+	"fizzbuzz-api/internal/fizzbuzzapi/config"
+	"fizzbuzz-api/internal/fizzbuzzapi/handlers"
+	"fizzbuzz-api/internal/fizzbuzzapi/logger"
+	"fizzbuzz-api/internal/fizzbuzzapi/openapi"
+	"fizzbuzz-api/internal/fizzbuzzapi/types"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testConfig = config.Config{
+	MaxFizzBuzzLimit: 100,
+	MaxStringLength:  10,
+	StatsStorage:     "inmemory",
+
+	MaxTemplateLength: 20,
+	MaxExpandedLength: 40,
+	StringLengthUnit:  "graphemes",
+
+	StatsKeying: "exact",
+	AdminAPIKey: "secret",
+
+	Compression:             "zstd,gzip",
+	CompressionMinSize:      1024,
+	MaxDecompressedBodySize: 1 << 20,
+	MaxSubmissionSize:       1 << 10,
+}
+
+func newTestRouter(t *testing.T) *gin.Engine {
+	return newTestRouterWith(t, testConfig)
+}
+
+func newTestRouterWith(t *testing.T, cfg config.Config) *gin.Engine {
+	s, err := NewServer(&cfg, logger.NewNopLogger())
+	require.NoError(t, err)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	s.Routes(router)
+	return router
+}
+
+func loadSpec(t *testing.T) *openapi3.T {
+	doc, err := openapi.Load()
+	require.NoError(t, err)
+	return doc
+}
+
+// Schemas of the document and the types they describe
+var specTypes = map[string]reflect.Type{
+	"FizzBuzzRequest":         reflect.TypeFor[types.FizzBuzzRequest](),
+	"FizzBuzzResponse":        reflect.TypeFor[types.FizzBuzzResponse](),
+	"FizzBuzzCompactResponse": reflect.TypeFor[types.FizzBuzzCompactResponse](),
+	"FizzBuzzExplainResponse": reflect.TypeFor[types.FizzBuzzExplainResponse](),
+	"FizzBuzzExplainedValue":  reflect.TypeFor[types.FizzBuzzExplainedValue](),
+	"FizzBuzzExplainSummary":  reflect.TypeFor[types.FizzBuzzExplainSummary](),
+	"FizzBuzzVerifyResponse":  reflect.TypeFor[types.FizzBuzzVerifyResponse](),
+	"FizzBuzzMismatch":        reflect.TypeFor[types.FizzBuzzMismatch](),
+	"FizzBuzzDiff":            reflect.TypeFor[types.FizzBuzzDiff](),
+	"FizzBuzzDiffHunk":        reflect.TypeFor[types.FizzBuzzDiffHunk](),
+	"FizzBuzzInferResponse":   reflect.TypeFor[types.FizzBuzzInferResponse](),
+	"FizzBuzzInferCandidate":  reflect.TypeFor[types.FizzBuzzInferCandidate](),
+	"FizzBuzzRule":            reflect.TypeFor[types.FizzBuzzRule](),
+	"FizzBuzzInferConflict":   reflect.TypeFor[types.FizzBuzzInferConflict](),
+	"FizzBuzzStats":           reflect.TypeFor[types.FizzBuzzStats](),
+	"FizzBuzzStatsSummary":    reflect.TypeFor[types.FizzBuzzStatsSummary](),
+	"FizzBuzzLimitBucket":     reflect.TypeFor[types.FizzBuzzLimitBucket](),
+	"FizzBuzzDivisorPair":     reflect.TypeFor[types.FizzBuzzDivisorPair](),
+	"FizzBuzzStringPair":      reflect.TypeFor[types.FizzBuzzStringPair](),
+	"StringViolation":         reflect.TypeFor[types.StringViolation](),
+	"ConfigReload":            reflect.TypeFor[types.ConfigReload](),
+	"CheckResult":             reflect.TypeFor[handlers.CheckResult](),
+}
+
+func Test_OpenAPI_RoutesInSync(t *testing.T) {
+	doc := loadSpec(t)
+
+	var routes []string
+	for _, r := range newTestRouter(t).Routes() {
+		path := regexp.MustCompile(`[:*](\w+)`).ReplaceAllString(r.Path, "{$1}")
+		routes = append(routes, r.Method+" "+path)
+	}
+
+	var documented []string
+	for path, item := range doc.Paths.Map() {
+		for method := range item.Operations() {
+			documented = append(documented, method+" "+path)
+		}
+	}
+
+	assert.ElementsMatch(t, routes, documented, "routes of Server.Routes and paths of openapi.json differ")
+}
+
+func Test_OpenAPI_TypesInSync(t *testing.T) {
+	doc := loadSpec(t)
+
+	for name, typ := range specTypes {
+		t.Run(name, func(t *testing.T) {
+			ref, ok := doc.Components.Schemas[name]
+			require.True(t, ok, "schema missing from openapi.json")
+			schema := ref.Value
+
+			var fields, required []string
+			for i := range typ.NumField() {
+				field := typ.Field(i)
+				jsonName, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+				if jsonName == "-" {
+					continue
+				}
+				fields = append(fields, jsonName)
+				if !strings.Contains(opts, "omitempty") {
+					required = append(required, jsonName)
+				}
+
+				prop, ok := schema.Properties[jsonName]
+				if assert.True(t, ok, "field %s is not documented", jsonName) {
+					assert.True(t, prop.Value.Type.Is(schemaType(field.Type)), "field %s: documented as %v, expected %s", jsonName, prop.Value.Type, schemaType(field.Type))
+				}
+			}
+
+			assert.ElementsMatch(t, fields, slices.Collect(maps.Keys(schema.Properties)), "documented properties differ")
+			assert.ElementsMatch(t, required, schema.Required, "required properties differ (fields without omitempty)")
+		})
+	}
+}
+
+func Test_OpenAPI_QueryParamsInSync(t *testing.T) {
+	doc := loadSpec(t)
+
+	// Query parameters of each operation, and the struct they are bound to
+	queries := []struct {
+		op  *openapi3.Operation
+		typ reflect.Type
+	}{
+		{doc.Paths.Value("/fizzbuzz/stats").Delete, reflect.TypeFor[types.FizzBuzzStatsFilter]()},
+		{doc.Paths.Value("/fizzbuzz/verify").Post, reflect.TypeFor[types.FizzBuzzRequest]()},
+		{doc.Paths.Value("/fizzbuzz/at").Get, reflect.TypeFor[types.FizzBuzzAtRequest]()},
+		{doc.Paths.Value("/fizzbuzz/count").Get, reflect.TypeFor[types.FizzBuzzCountRequest]()},
+		{doc.Paths.Value("/fizzbuzz/window").Get, reflect.TypeFor[types.FizzBuzzWindowRequest]()},
+	}
+	for _, q := range queries {
+		documented := map[string]bool{}
+		for _, p := range q.op.Parameters {
+			if p.Value.In == openapi3.ParameterInQuery {
+				documented[p.Value.Name] = p.Value.Required
+			}
+		}
+		fields := map[string]bool{}
+		for i := range q.typ.NumField() {
+			field := q.typ.Field(i)
+			if field.Tag.Get("form") == "-" {
+				continue
+			}
+			fields[field.Tag.Get("form")] = field.Tag.Get("binding") == "required"
+		}
+		assert.Equal(t, fields, documented, "%s parameters do not match %s", q.op.OperationID, q.typ)
+	}
+}
+
+func Test_OpenAPI_ErrorResponses(t *testing.T) {
+	doc := loadSpec(t)
+
+	for path, item := range doc.Paths.Map() {
+		for method, op := range item.Operations() {
+			if op.Security != nil {
+				assert.NotNil(t, op.Responses.Status(http.StatusUnauthorized), "%s %s: missing 401", method, path)
+				assert.NotNil(t, op.Responses.Status(http.StatusForbidden), "%s %s: missing 403", method, path)
+			}
+			if op.RequestBody != nil || len(op.Parameters) > 0 {
+				assert.NotNil(t, op.Responses.Status(http.StatusBadRequest), "%s %s: missing 400", method, path)
+			}
+			if op.RequestBody != nil {
+				assert.NotNil(t, op.Responses.Status(http.StatusUnsupportedMediaType), "%s %s: missing 415", method, path)
+			}
+			// Responses written as JSON are negotiated, except the document itself
+			if ok := op.Responses.Status(http.StatusOK); ok != nil && ok.Value.Content.Get("application/json") != nil && path != "/openapi.json" {
+				assert.NotNil(t, op.Responses.Status(http.StatusNotAcceptable), "%s %s: missing 406", method, path)
+			}
+		}
+	}
+}
+
+func Test_OpenAPI_ServedWithLimits(t *testing.T) {
+	assert := assert.New(t)
+	w := httptest.NewRecorder()
+	newTestRouter(t).ServeHTTP(w, httptest.NewRequest("GET", "/openapi.json", nil))
+	assert.Equal(200, w.Code)
+
+	var doc struct {
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]struct {
+					Maximum    *int   `json:"maximum"`
+					MaxLength  *int   `json:"maxLength"`
+					LengthUnit string `json:"x-length-unit"`
+				} `json:"properties"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	assert.NoError(json.Unmarshal(w.Body.Bytes(), &doc))
+	request := doc.Components.Schemas["FizzBuzzRequest"].Properties
+	assert.Equal(100, *request["limit"].Maximum)
+	assert.Equal(20, *request["str1"].MaxLength, "templates may be longer than plain strings")
+	assert.Equal(20, *request["str2"].MaxLength)
+	assert.Equal("graphemes", request["str1"].LengthUnit)
+}
+
+func Test_OpenAPI_RequestValidation(t *testing.T) {
+	router := newTestRouter(t)
+
+	tests := []struct {
+		name   string
+		body   string
+		code   int
+		reason string
+	}{
+		{"valid", `{"int1":3,"int2":5,"limit":15,"str1":"fizz","str2":"buzz"}`, 200, ""},
+		{"wrong type", `{"int1":"three","int2":5,"limit":15,"str1":"fizz","str2":"buzz"}`, 400, "int1"},
+		{"missing field", `{"int1":3,"int2":5,"str1":"fizz","str2":"buzz"}`, 400, `property \"limit\" is missing, or property \"end\" is missing`},
+		{"range", `{"int1":3,"int2":5,"start":-5,"end":5,"step":2,"str1":"fizz","str2":"buzz"}`, 200, `["buzz","fizz","-1","1","fizz","buzz"]`},
+		{"limit and end", `{"int1":3,"int2":5,"limit":15,"end":15,"str1":"fizz","str2":"buzz"}`, 400, "oneOf"},
+		{"zero step", `{"int1":3,"int2":5,"end":15,"step":0,"str1":"fizz","str2":"buzz"}`, 400, "step"},
+		{"template", `{"int1":3,"int2":5,"limit":5,"str1":"f{n}","str2":"{n:roman}","number_format":"02"}`, 200, `["01","02","f03","04","V"]`},
+		{"unknown number format", `{"int1":3,"int2":5,"limit":5,"str1":"fizz","str2":"buzz","number_format":"octal"}`, 400, "number_format"},
+		{"template too long", `{"int1":3,"int2":5,"limit":5,"str1":"fizz{n}buzz{n}fizz{n}","str2":"buzz"}`, 422, "template length"},
+		{"emoji counted as graphemes", `{"int1":3,"int2":5,"limit":3,"str1":"🍕🍕🍕🍕🍕🍕","str2":"👩‍💻"}`, 200, `["1","2","🍕🍕🍕🍕🍕🍕"]`},
+		{"unsafe characters", `{"int1":3,"int2":5,"limit":3,"str1":"fizz\u202e","str2":"\u0007buzz\u200b"}`, 400,
+			`"violations":[{"field":"str1","offset":4,"character":"U+202E","reason":"bidi"},{"field":"str2","offset":0,"character":"U+0007","reason":"control"},{"field":"str2","offset":5,"character":"U+200B","reason":"zero_width"}]`},
+		{"below minimum", `{"int1":3,"int2":5,"limit":0,"str1":"fizz","str2":"buzz"}`, 400, "limit"},
+		// Configured limits keep their own status code
+		{"limit exceeded", `{"int1":3,"int2":5,"limit":1000,"str1":"fizz","str2":"buzz"}`, 422, "limit"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/fizzbuzz/generate", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.code, w.Code, w.Body.String())
+			assert.Contains(t, w.Body.String(), tt.reason)
+		})
+	}
+}
+
+func Test_Submissions(t *testing.T) {
+	router := newTestRouter(t)
+
+	tests := []struct {
+		name        string
+		path        string
+		contentType string
+		body        string
+		code        int
+		reason      string
+	}{
+		{"verified", "/fizzbuzz/verify?int1=3&int2=5&limit=3&str1=fizz&str2=buzz", "text/plain", "1\n2\nfizz\n", 200, `"pass":true`},
+		{"query validated", "/fizzbuzz/verify?int1=three&int2=5&limit=3&str1=fizz&str2=buzz", "text/plain", "1\n", 400, "int1"},
+		{"body checked by the handler", "/fizzbuzz/verify?int1=3&int2=5&limit=3&str1=fizz&str2=buzz", "application/json", `[1,2]`, 400, "request body"},
+		{"text too large", "/fizzbuzz/verify?int1=3&int2=5&limit=3&str1=fizz&str2=buzz", "text/plain", strings.Repeat(strings.Repeat("fizz", 100)+"\n", 3), 413, "invalid_request"},
+		{"json too large", "/fizzbuzz/infer", "application/json", `["` + strings.Repeat("a", 2000) + `"]`, 413, "invalid_request"},
+		{"too many values", "/fizzbuzz/verify?int1=3&int2=5&limit=3&str1=fizz&str2=buzz", "text/plain", strings.Repeat("1\n", 102), 422, "limit_exceeded"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.code, w.Code, w.Body.String())
+			assert.Contains(t, w.Body.String(), tt.reason)
+		})
+	}
+}
+
+func Test_OpenAPI_AuthBeforeValidation(t *testing.T) {
+	w := httptest.NewRecorder()
+	newTestRouter(t).ServeHTTP(w, httptest.NewRequest("DELETE", "/fizzbuzz/stats?int1=three", nil))
+	assert.Equal(t, 401, w.Code)
+}
+
+func schemaType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return openapi3.TypeInteger
+	case reflect.Float32, reflect.Float64:
+		return openapi3.TypeNumber
+	case reflect.String:
+		return openapi3.TypeString
+	case reflect.Bool:
+		return openapi3.TypeBoolean
+	case reflect.Slice, reflect.Array:
+		return openapi3.TypeArray
+	case reflect.Pointer:
+		return schemaType(t.Elem())
+	}
+	if t == reflect.TypeFor[time.Time]() || t == reflect.TypeFor[types.BigInt]() {
+		return openapi3.TypeString
+	}
+	return openapi3.TypeObject
+}
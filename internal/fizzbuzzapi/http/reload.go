@@ -0,0 +1,63 @@
+package http
+
+import (
+	"fizzbuzz-api/internal/fizzbuzzapi/config"
+	"fizzbuzz-api/internal/fizzbuzzapi/logger"
+	"fizzbuzz-api/internal/fizzbuzzapi/reload"
+	"fizzbuzz-api/internal/fizzbuzzapi/types"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// EnableReload lets the server reload its config on SIGHUP and, when file is set, whenever the file changes.
+// load must resolve the config from the same sources as at startup.
+func (s *Server) EnableReload(file string, load reload.LoadFunc) {
+	s.configFile = file
+	s.reloader = reload.NewReloader(s.cfg, load, s.applyConfig, s.log)
+}
+
+// applyConfig swaps the reloadable settings, see config.ReloadableFields
+func (s *Server) applyConfig(cfg *config.Config) {
+	s.fizzbuzzController.SetLimits(types.FizzBuzzLimits{
+		MaxLimit:          cfg.MaxFizzBuzzLimit,
+		MaxStringLength:   cfg.MaxStringLength,
+		MaxTemplateLength: cfg.MaxTemplateLength,
+		MaxExpandedLength: cfg.MaxExpandedLength,
+		StringLengthUnit:  cfg.StringLengthUnit,
+	})
+	if err := logger.SetLevel(cfg.LogLevel); err != nil {
+		s.log.Error("failed to set log level", "error", err)
+	}
+}
+
+// startReload listens for reload triggers. It returns a function stopping them.
+func (s *Server) startReload() func() {
+	if s.reloader == nil {
+		return func() {}
+	}
+
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-hup:
+				s.reloader.Reload(reload.TriggerSignal)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	if s.configFile != "" && s.cfg.ConfigReloadInterval > 0 {
+		go s.reloader.WatchFile(s.configFile, s.cfg.ConfigReloadInterval, done)
+		s.log.Info("watching config file for changes", "file", s.configFile, "interval", s.cfg.ConfigReloadInterval)
+	}
+
+	return func() {
+		signal.Stop(hup)
+		close(done)
+	}
+}
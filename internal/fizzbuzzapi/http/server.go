@@ -2,91 +2,295 @@ package http
 
 import (
 	"context"
+	"errors"
+	fizzbuzzv1 "fizzbuzz-api/api/proto/fizzbuzz/v1"
 	"fizzbuzz-api/internal/fizzbuzzapi/config"
 	"fizzbuzz-api/internal/fizzbuzzapi/controllers"
 	"fizzbuzz-api/internal/fizzbuzzapi/handlers"
 	"fizzbuzz-api/internal/fizzbuzzapi/logger"
+	"fizzbuzz-api/internal/fizzbuzzapi/middleware"
+	"fizzbuzz-api/internal/fizzbuzzapi/openapi"
+	"fizzbuzz-api/internal/fizzbuzzapi/reload"
 	"fizzbuzz-api/internal/fizzbuzzapi/types"
+	"fmt"
+	"io"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	_ "google.golang.org/grpc/encoding/gzip" // Lets gRPC clients ask for gzip compressed messages
 )
 
 type Server struct {
 	HttpServer      *http.Server
+	GrpcServer      *grpc.Server // Nil when the gRPC API is disabled
 	cfg             *config.Config
 	log             logger.Logger
 	fizzbuzzHandler *handlers.FizzBuzzHandler
-}
+	statsAdmin      handlers.FizzBuzzStatsAdministrator
 
-func NewServer(log logger.Logger) (*Server, error) {
-	cfg, err := config.LoadConfig(log)
-	if err != nil {
-		return nil, err
-	}
+	healthHandler      *handlers.HealthHandler
+	fizzbuzzController *controllers.FizzBuzzController
+	apiDoc             *openapi3.T
+	reloader           *reload.Reloader
+	configFile         string
+	shutdownHooks      []shutdownHook
+}
 
+func NewServer(cfg *config.Config, log logger.Logger) (*Server, error) {
 	// Define and initialize controllers
 	fizzbuzzLimits := types.FizzBuzzLimits{
-		MaxLimit:        cfg.MaxFizzBuzzLimit,
-		MaxStringLength: cfg.MaxStringLength,
+		MaxLimit:          cfg.MaxFizzBuzzLimit,
+		MaxStringLength:   cfg.MaxStringLength,
+		MaxTemplateLength: cfg.MaxTemplateLength,
+		MaxExpandedLength: cfg.MaxExpandedLength,
+		StringLengthUnit:  cfg.StringLengthUnit,
 	}
 	fizzbuzzController := controllers.NewFizzBuzzController(fizzbuzzLimits, log)
 
+	statsKeying, err := controllers.ParseStatsKeying(cfg.StatsKeying)
+	if err != nil {
+		return nil, err
+	}
+
 	var fizzBuzzStatsController *controllers.FizzBuzzStatsController
 	// Note: Currently only in-memory stats recorder is implemented, placeholder for future extensions
 	switch cfg.StatsStorage {
 	case "inmemory":
-		log.Info("using in-memory stats recorder")
-		fizzBuzzStatsController = controllers.NewFizzBuzzStatsController(log)
+		log.Info("using in-memory stats recorder", "keying", statsKeying)
+		fizzBuzzStatsController = controllers.NewFizzBuzzStatsController(statsKeying, log)
 	default:
-		log.Info("using in-memory stats recorder (default)")
-		fizzBuzzStatsController = controllers.NewFizzBuzzStatsController(log)
+		log.Info("unknown stats storage, using in-memory stats recorder (default)", "storage", cfg.StatsStorage, "keying", statsKeying)
+		fizzBuzzStatsController = controllers.NewFizzBuzzStatsController(statsKeying, log)
 	}
 
 	// Define and initialize handlers
 	fizzbuzzHandler := handlers.NewFizzBuzzHandler(cfg, log, fizzbuzzController, fizzBuzzStatsController)
+	healthHandler := handlers.NewHealthHandler()
+	healthHandler.Register("stats", handlers.CheckerFunc(fizzBuzzStatsController.Ping))
+
+	apiDoc, err := openapi.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig, err := newTLSConfig(cfg, log)
+	if err != nil {
+		return nil, err
+	}
+
+	var grpcServer *grpc.Server
+	if cfg.GRPCPort != "" {
+		var opts []grpc.ServerOption
+		if tlsConfig != nil {
+			opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+		}
+		grpcServer = grpc.NewServer(opts...)
+		fizzbuzzv1.RegisterFizzBuzzServiceServer(grpcServer, handlers.NewFizzBuzzGRPCHandler(fizzbuzzHandler, healthHandler))
+	}
 
 	router := gin.Default()
+	router.Use(middleware.CallerIdentity())
 	return &Server{
-		HttpServer: &http.Server{Addr: cfg.Host + ":" + cfg.Port, Handler: router},
+		HttpServer: &http.Server{
+			Addr:              net.JoinHostPort(cfg.Host, cfg.Port),
+			Handler:           router,
+			ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+			ReadTimeout:       cfg.ReadTimeout,
+			WriteTimeout:      cfg.WriteTimeout,
+			IdleTimeout:       cfg.IdleTimeout,
+			TLSConfig:         tlsConfig,
+		},
+		GrpcServer: grpcServer,
 		cfg:        cfg,
 		log:        log,
 
 		fizzbuzzHandler: fizzbuzzHandler,
+		statsAdmin:      fizzBuzzStatsController,
+
+		healthHandler:      healthHandler,
+		fizzbuzzController: fizzbuzzController,
+		apiDoc:             apiDoc,
 	}, nil
 }
 
-func (s *Server) Run() {
+// Run serves requests until SIGINT or SIGTERM, then shuts down gracefully.
+// It returns early with an error when the server cannot listen or stops serving.
+func (s *Server) Run() error {
 	s.Routes(s.HttpServer.Handler.(*gin.Engine))
 
+	// Listen before anything else, so that bind errors make the process fail fast
+	listener, err := net.Listen("tcp", s.HttpServer.Addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.HttpServer.Addr, err)
+	}
+	var grpcListener net.Listener
+	if s.GrpcServer != nil {
+		grpcAddr := net.JoinHostPort(s.cfg.Host, s.cfg.GRPCPort)
+		grpcListener, err = net.Listen("tcp", grpcAddr)
+		if err != nil {
+			listener.Close()
+			return fmt.Errorf("failed to listen on %s: %w", grpcAddr, err)
+		}
+	}
+
+	serveErr := make(chan error, 2)
 	go func() {
-		if err := s.HttpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			s.log.Error("failed to start HTTP server", "error", err)
+		if s.HttpServer.TLSConfig != nil {
+			serveErr <- fmt.Errorf("HTTP server stopped: %w", s.HttpServer.ServeTLS(listener, "", ""))
+			return
 		}
+		serveErr <- fmt.Errorf("HTTP server stopped: %w", s.HttpServer.Serve(listener))
 	}()
-	s.log.Info("fizzbuzz-api server running", "host", s.cfg.Host, "port", s.cfg.Port)
+	s.log.Info("fizzbuzz-api server running", "host", s.cfg.Host, "port", s.cfg.Port, "tls", s.HttpServer.TLSConfig != nil)
+	if s.GrpcServer != nil {
+		go func() {
+			serveErr <- fmt.Errorf("gRPC server stopped: %w", s.GrpcServer.Serve(grpcListener))
+		}()
+		s.log.Info("fizzbuzz-api gRPC server running", "host", s.cfg.Host, "port", s.cfg.GRPCPort)
+	}
+
+	// Shutdown order: flush stats, stop background workers, close stores
+	if f, ok := s.statsAdmin.(flusher); ok {
+		s.OnShutdown("flush stats", f.Flush)
+	}
+	s.OnShutdown("stop stats retention", hookFunc(s.startStatsRetention()))
+	s.OnShutdown("stop config reload", hookFunc(s.startReload()))
+	if closer, ok := s.statsAdmin.(io.Closer); ok {
+		s.OnShutdown("close stats storage", func(ctx context.Context) error { return closer.Close() })
+	}
 
 	// Graceful shutdown on interrupt signal
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM, os.Interrupt)
-	<-quit
+	defer signal.Stop(quit)
+
+	select {
+	case err := <-serveErr:
+		s.log.Error("server stopped unexpectedly", "error", err)
+		s.HttpServer.Close()
+		if s.GrpcServer != nil {
+			s.GrpcServer.Stop()
+		}
+		return errors.Join(err, s.runShutdownHooks(s.cfg.ShutdownTimeout))
+	case <-quit:
+	}
+
+	// Fail readiness first so that load balancers stop routing requests before shutdown
+	s.healthHandler.SetDraining()
+	if s.cfg.ShutdownDrainDelay > 0 {
+		s.log.Info("draining before shutdown", "delay", s.cfg.ShutdownDrainDelay)
+		select {
+		case <-time.After(s.cfg.ShutdownDrainDelay):
+		case <-quit:
+			s.log.Info("second signal received, skipping drain delay")
+		}
+	}
+
 	s.log.Info("shutting down server...")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), s.cfg.ShutdownTimeout)
 	defer cancel()
 
+	var errs []error
 	if err := s.HttpServer.Shutdown(ctx); err != nil {
 		s.log.Error("server forced to shutdown", "error", err)
+		errs = append(errs, err)
+	}
+	if s.GrpcServer != nil {
+		if err := stopGRPC(ctx, s.GrpcServer); err != nil {
+			s.log.Error("gRPC server forced to shutdown", "error", err)
+			errs = append(errs, err)
+		}
+	}
+	errs = append(errs, s.runShutdownHooks(s.cfg.ShutdownTimeout))
+	return errors.Join(errs...)
+}
+
+// stopGRPC waits for in-flight RPCs to complete, and cancels them when ctx expires first
+func stopGRPC(ctx context.Context, server *grpc.Server) error {
+	done := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		server.Stop()
+		return ctx.Err()
 	}
 }
 
 func (s *Server) Routes(router *gin.Engine) {
+	router.Use(
+		middleware.Compress(s.cfg.CompressionEncodings(), s.cfg.CompressionMinSize),
+		middleware.Decompress(int64(s.cfg.MaxDecompressedBodySize)),
+		middleware.Localize(),
+	)
+
 	// Define API routes here
-	router.GET("/fizzbuzz/health", handlers.HealthCheck)
+	router.GET("/fizzbuzz/health", s.healthHandler.HealthCheck)
+	router.GET("/livez", s.healthHandler.Livez)
+	router.GET("/readyz", s.healthHandler.Readyz)
+
+	router.GET("/openapi.json", handlers.GetOpenAPI(s.fizzbuzzController.Limits, s.log))
+	router.GET("/docs", handlers.GetDocs)
+
+	// Routes taking parameters are validated against the OpenAPI document
+	validate := middleware.ValidateRequest(s.apiDoc, s.fizzbuzzHandler.RejectRequest)
 
-	router.POST("/fizzbuzz/generate", s.fizzbuzzHandler.GenerateFizzBuzz)
+	router.POST("/fizzbuzz/generate", validate, s.fizzbuzzHandler.GenerateFizzBuzz)
+	router.POST("/fizzbuzz/generate/stream", validate, s.fizzbuzzHandler.StreamFizzBuzz)
+	// Submitted sequences are graded as they are read rather than validated beforehand, their size being bound instead
+	limitSubmission := middleware.LimitBody(int64(s.cfg.MaxSubmissionSize))
+	validateQuery := middleware.ValidateQuery(s.apiDoc, s.fizzbuzzHandler.RejectRequest)
+	router.POST("/fizzbuzz/verify", limitSubmission, validateQuery, s.fizzbuzzHandler.VerifyFizzBuzz)
+	router.POST("/fizzbuzz/infer", limitSubmission, validateQuery, s.fizzbuzzHandler.InferFizzBuzz)
+	router.GET("/fizzbuzz/at", validate, s.fizzbuzzHandler.GetValueAt)
+	router.GET("/fizzbuzz/count", validate, s.fizzbuzzHandler.GetCount)
+	router.GET("/fizzbuzz/window", validate, s.fizzbuzzHandler.GetWindow)
 	router.GET("/fizzbuzz/stats", s.fizzbuzzHandler.GetFizzBuzzStats)
+	router.GET("/fizzbuzz/stats/summary", s.fizzbuzzHandler.GetFizzBuzzStatsSummary)
+
+	// Admin routes
+	admin := middleware.RequireAPIKey(s.cfg.AdminAPIKey)
+	router.DELETE("/fizzbuzz/stats", admin, validate, s.fizzbuzzHandler.ResetFizzBuzzStats)
+	router.GET("/fizzbuzz/admin/config/reloads", admin, handlers.GetConfigReloads(s.reloader))
+}
+
+// startStatsRetention periodically drops stats entries older than the configured retention.
+// It returns a function stopping the background pruning.
+func (s *Server) startStatsRetention() func() {
+	if s.cfg.StatsRetentionDays <= 0 || s.statsAdmin == nil {
+		return func() {}
+	}
+
+	retention := time.Duration(s.cfg.StatsRetentionDays) * 24 * time.Hour
+	ticker := time.NewTicker(time.Hour)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				s.statsAdmin.PruneStats(time.Now().Add(-retention))
+			case <-done:
+				return
+			}
+		}
+	}()
+	s.log.Info("stats retention enabled", "days", s.cfg.StatsRetentionDays)
+
+	return func() {
+		ticker.Stop()
+		close(done)
+	}
 }
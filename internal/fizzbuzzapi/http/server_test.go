@@ -0,0 +1,68 @@
+package http
+
+import (
+	"fizzbuzz-api/internal/fizzbuzzapi/logger"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// listenLocal occupies a free local port and returns it
+func listenLocal(t *testing.T) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+	return strconv.Itoa(l.Addr().(*net.TCPAddr).Port)
+}
+
+// runServer runs the server and fails the test when it does not return promptly
+func runServer(t *testing.T, s *Server) error {
+	done := make(chan error, 1)
+	go func() { done <- s.Run() }()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(5 * time.Second):
+		t.Fatal("server did not fail fast")
+		return nil
+	}
+}
+
+func Test_Run_BindError(t *testing.T) {
+	cfg := testConfig
+	cfg.Host, cfg.Port = "127.0.0.1", listenLocal(t)
+	cfg.ShutdownTimeout = time.Second
+
+	s, err := NewServer(&cfg, logger.NewNopLogger())
+	require.NoError(t, err)
+	err = runServer(t, s)
+	assert.ErrorContains(t, err, "failed to listen on 127.0.0.1:"+cfg.Port)
+}
+
+func Test_Run_GRPCBindError(t *testing.T) {
+	assert := assert.New(t)
+	cfg := testConfig
+	cfg.Host, cfg.GRPCPort = "127.0.0.1", listenLocal(t)
+	cfg.ShutdownTimeout = time.Second
+	// Pick a free port for the HTTP server, released right away so that Run can bind it
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	cfg.Port = strconv.Itoa(l.Addr().(*net.TCPAddr).Port)
+	l.Close()
+
+	s, err := NewServer(&cfg, logger.NewNopLogger())
+	require.NoError(t, err)
+	err = runServer(t, s)
+	assert.ErrorContains(err, "failed to listen on 127.0.0.1:"+cfg.GRPCPort)
+
+	// The HTTP listener is released
+	l, err = net.Listen("tcp", "127.0.0.1:"+cfg.Port)
+	assert.NoError(err)
+	if err == nil {
+		l.Close()
+	}
+}
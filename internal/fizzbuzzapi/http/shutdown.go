@@ -0,0 +1,54 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Optional interface for stores buffering writes, flushed on shutdown
+type flusher interface {
+	Flush(ctx context.Context) error
+}
+
+type shutdownHook struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+// OnShutdown registers a hook run after the HTTP server stopped accepting requests.
+// Hooks run in registration order, sharing their own shutdown timeout.
+func (s *Server) OnShutdown(name string, hook func(ctx context.Context) error) {
+	s.shutdownHooks = append(s.shutdownHooks, shutdownHook{name: name, run: hook})
+}
+
+// runShutdownHooks runs the hooks within timeout. They get a deadline of their own rather than what is left
+// of the one given to in-flight requests, so that slow requests cannot prevent the stats from being flushed.
+func (s *Server) runShutdownHooks(timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var errs []error
+	for _, hook := range s.shutdownHooks {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, fmt.Errorf("shutdown hook %q skipped: %w", hook.name, err))
+			continue
+		}
+		if err := hook.run(ctx); err != nil {
+			s.log.Error("shutdown hook failed", "hook", hook.name, "error", err)
+			errs = append(errs, fmt.Errorf("shutdown hook %q: %w", hook.name, err))
+			continue
+		}
+		s.log.Info("shutdown hook done", "hook", hook.name)
+	}
+	return errors.Join(errs...)
+}
+
+// hookFunc adapts a stop function to a shutdown hook
+func hookFunc(stop func()) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		stop()
+		return nil
+	}
+}
@@ -0,0 +1,48 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fizzbuzz-api/internal/fizzbuzzapi/logger"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RunShutdownHooks_Order(t *testing.T) {
+	assert := assert.New(t)
+	s := &Server{log: logger.NewNopLogger()}
+
+	var order []string
+	s.OnShutdown("first", func(ctx context.Context) error {
+		order = append(order, "first")
+		return errors.New("flush failed")
+	})
+	s.OnShutdown("second", func(ctx context.Context) error {
+		order = append(order, "second")
+		return nil
+	})
+
+	err := s.runShutdownHooks(time.Second)
+	assert.Equal([]string{"first", "second"}, order, "a failing hook does not prevent the next ones")
+	assert.ErrorContains(err, `shutdown hook "first": flush failed`)
+}
+
+func Test_RunShutdownHooks_Timeout(t *testing.T) {
+	s := &Server{log: logger.NewNopLogger()}
+	ran := false
+	s.OnShutdown("slow", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	s.OnShutdown("late", func(ctx context.Context) error {
+		ran = true
+		return nil
+	})
+
+	err := s.runShutdownHooks(10 * time.Millisecond)
+	assert.False(t, ran)
+	assert.ErrorContains(t, err, `shutdown hook "late" skipped`)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
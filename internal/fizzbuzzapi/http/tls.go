@@ -0,0 +1,163 @@
+package http
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fizzbuzz-api/internal/fizzbuzzapi/config"
+	"fizzbuzz-api/internal/fizzbuzzapi/logger"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// Minimum time between two checks of the certificate files for changes
+const certCheckInterval = time.Second
+
+var tlsVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// Cipher suites of the "strict" policy: forward secrecy and AEAD only. TLS 1.3 suites are not configurable.
+var strictCipherSuites = []uint16{
+	tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256,
+	tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256,
+}
+
+// certReloader serves the certificate and client CA pool from files, reloading them when they change.
+// A rotation producing invalid files is logged and the previous certificates are kept.
+type certReloader struct {
+	certFile, keyFile, caFile string
+	log                       logger.Logger
+
+	mu        sync.RWMutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+	modTimes  [3]time.Time
+	lastCheck time.Time
+}
+
+func newCertReloader(certFile, keyFile, caFile string, log logger.Logger) (*certReloader, error) {
+	r := &certReloader{certFile: certFile, keyFile: keyFile, caFile: caFile, log: log}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	r.modTimes = r.currentModTimes()
+	r.lastCheck = time.Now()
+	return r, nil
+}
+
+func (r *certReloader) load() error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+
+	var pool *x509.CertPool
+	if r.caFile != "" {
+		pem, err := os.ReadFile(r.caFile)
+		if err != nil {
+			return fmt.Errorf("failed to read client CA bundle: %w", err)
+		}
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return errors.New("failed to load client CA bundle: no certificate found")
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cert = &cert
+	r.clientCAs = pool
+	return nil
+}
+
+func (r *certReloader) currentModTimes() [3]time.Time {
+	var times [3]time.Time
+	for i, path := range []string{r.certFile, r.keyFile, r.caFile} {
+		if info, err := os.Stat(path); err == nil {
+			times[i] = info.ModTime()
+		}
+	}
+	return times
+}
+
+// maybeReload reloads the files if they changed since the last check, checking at most once per certCheckInterval
+func (r *certReloader) maybeReload() {
+	r.mu.Lock()
+	if time.Since(r.lastCheck) < certCheckInterval {
+		r.mu.Unlock()
+		return
+	}
+	r.lastCheck = time.Now()
+	modTimes := r.currentModTimes()
+	changed := modTimes != r.modTimes
+	r.modTimes = modTimes
+	r.mu.Unlock()
+
+	if !changed {
+		return
+	}
+	if err := r.load(); err != nil {
+		r.log.Error("failed to reload TLS certificates, keeping the previous ones", "error", err)
+		return
+	}
+	r.log.Info("TLS certificates reloaded", "cert", r.certFile, "client_ca", r.caFile)
+}
+
+func (r *certReloader) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.maybeReload()
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, nil
+}
+
+func (r *certReloader) getClientCAs() *x509.CertPool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.clientCAs
+}
+
+// newTLSConfig builds the server TLS config from the settings, or returns nil when TLS is disabled
+func newTLSConfig(cfg *config.Config, log logger.Logger) (*tls.Config, error) {
+	if !cfg.TLSEnabled() {
+		return nil, nil
+	}
+
+	reloader, err := newCertReloader(cfg.TLSCertFile, cfg.TLSKeyFile, cfg.TLSClientCAFile, log)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:     tlsVersions[cfg.TLSMinVersion],
+		GetCertificate: reloader.getCertificate,
+		// Set explicitly since the config returned by GetConfigForClient is used as is, gRPC requires h2
+		NextProtos: []string{"h2", "http/1.1"},
+	}
+	if cfg.TLSCipherPolicy == "strict" {
+		tlsConfig.CipherSuites = strictCipherSuites
+	}
+
+	if cfg.TLSClientCAFile != "" {
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+		if cfg.TLSClientAuth == "verify-if-given" {
+			tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+		}
+		// Resolve the client CA pool on each handshake so that rotations apply to new connections
+		tlsConfig.GetConfigForClient = func(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+			reloader.maybeReload()
+			clientConfig := tlsConfig.Clone()
+			clientConfig.GetConfigForClient = nil
+			clientConfig.ClientCAs = reloader.getClientCAs()
+			return clientConfig, nil
+		}
+	}
+	return tlsConfig, nil
+}
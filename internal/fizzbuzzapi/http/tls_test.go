@@ -0,0 +1,241 @@
+package http
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fizzbuzz-api/internal/fizzbuzzapi/config"
+	"fizzbuzz-api/internal/fizzbuzzapi/logger"
+	"fizzbuzz-api/internal/fizzbuzzapi/middleware"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCert struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+	keyPEM  []byte
+}
+
+// newTestCert issues a certificate signed by parent, or a self-signed CA when parent is nil
+func newTestCert(t *testing.T, cn string, parent *testCert, serial int64) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: cn, Organization: []string{"fizzbuzz"}},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	signer, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage |= x509.KeyUsageCertSign
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return &testCert{
+		cert:    cert,
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+func (c *testCert) tlsCertificate(t *testing.T) tls.Certificate {
+	cert, err := tls.X509KeyPair(c.certPEM, c.keyPEM)
+	require.NoError(t, err)
+	return cert
+}
+
+func writeFile(t *testing.T, path string, data []byte) {
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+}
+
+// startTLSServer serves the caller identity over TLS and returns the server URL
+func startTLSServer(t *testing.T, cfg *config.Config) string {
+	tlsConfig, err := newTLSConfig(cfg, logger.NewNopLogger())
+	require.NoError(t, err)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.CallerIdentity())
+	router.GET("/whoami", func(c *gin.Context) {
+		c.String(http.StatusOK, middleware.Caller(c))
+	})
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := &http.Server{Handler: router, TLSConfig: tlsConfig}
+	go server.ServeTLS(listener, "", "")
+	t.Cleanup(func() { server.Close() })
+	return "https://" + listener.Addr().String()
+}
+
+func newTestClient(ca *testCert, clientCert *tls.Certificate) *http.Client {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+	tlsConfig := &tls.Config{RootCAs: pool}
+	if clientCert != nil {
+		tlsConfig.Certificates = []tls.Certificate{*clientCert}
+	}
+	return &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig, DisableKeepAlives: true}}
+}
+
+func get(client *http.Client, url string) (string, error) {
+	resp, err := client.Get(url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	return string(body), err
+}
+
+type tlsFixture struct {
+	dir    string
+	ca     *testCert
+	server *testCert
+	cfg    *config.Config
+}
+
+func newTLSFixture(t *testing.T) *tlsFixture {
+	dir := t.TempDir()
+	ca := newTestCert(t, "test-ca", nil, 1)
+	server := newTestCert(t, "server", ca, 2)
+	writeFile(t, filepath.Join(dir, "server.crt"), server.certPEM)
+	writeFile(t, filepath.Join(dir, "server.key"), server.keyPEM)
+	writeFile(t, filepath.Join(dir, "ca.crt"), ca.certPEM)
+
+	return &tlsFixture{
+		dir:    dir,
+		ca:     ca,
+		server: server,
+		cfg: &config.Config{
+			TLSCertFile:     filepath.Join(dir, "server.crt"),
+			TLSKeyFile:      filepath.Join(dir, "server.key"),
+			TLSMinVersion:   "1.2",
+			TLSCipherPolicy: "strict",
+			TLSClientAuth:   "require",
+		},
+	}
+}
+
+func Test_TLS_Disabled(t *testing.T) {
+	tlsConfig, err := newTLSConfig(&config.Config{}, logger.NewNopLogger())
+	assert.NoError(t, err)
+	assert.Nil(t, tlsConfig)
+}
+
+func Test_TLS_InvalidFiles(t *testing.T) {
+	f := newTLSFixture(t)
+	f.cfg.TLSKeyFile = filepath.Join(f.dir, "missing.key")
+	_, err := newTLSConfig(f.cfg, logger.NewNopLogger())
+	assert.Error(t, err)
+}
+
+func Test_TLS_Serve(t *testing.T) {
+	f := newTLSFixture(t)
+	url := startTLSServer(t, f.cfg)
+
+	caller, err := get(newTestClient(f.ca, nil), url+"/whoami")
+	assert.NoError(t, err)
+	assert.Empty(t, caller, "callers without client certificate are anonymous")
+}
+
+func Test_MutualTLS(t *testing.T) {
+	assert := assert.New(t)
+	f := newTLSFixture(t)
+	f.cfg.TLSClientCAFile = filepath.Join(f.dir, "ca.crt")
+	url := startTLSServer(t, f.cfg)
+
+	_, err := get(newTestClient(f.ca, nil), url+"/whoami")
+	assert.Error(err, "a client certificate is required")
+
+	clientCert := newTestCert(t, "client-a", f.ca, 3).tlsCertificate(t)
+	caller, err := get(newTestClient(f.ca, &clientCert), url+"/whoami")
+	assert.NoError(err)
+	assert.Equal("CN=client-a,O=fizzbuzz", caller)
+
+	otherCA := newTestCert(t, "other-ca", nil, 4)
+	untrusted := newTestCert(t, "client-b", otherCA, 5).tlsCertificate(t)
+	_, err = get(newTestClient(f.ca, &untrusted), url+"/whoami")
+	assert.Error(err, "client certificates must be issued by the client CA")
+}
+
+func Test_MutualTLS_VerifyIfGiven(t *testing.T) {
+	f := newTLSFixture(t)
+	f.cfg.TLSClientCAFile = filepath.Join(f.dir, "ca.crt")
+	f.cfg.TLSClientAuth = "verify-if-given"
+	url := startTLSServer(t, f.cfg)
+
+	caller, err := get(newTestClient(f.ca, nil), url+"/whoami")
+	assert.NoError(t, err)
+	assert.Empty(t, caller)
+}
+
+func Test_TLS_CertificateRotation(t *testing.T) {
+	assert := assert.New(t)
+	f := newTLSFixture(t)
+	url := startTLSServer(t, f.cfg)
+
+	// Rotate to a certificate issued by a new CA, with a modification time the reloader notices
+	newCA := newTestCert(t, "new-ca", nil, 10)
+	rotated := newTestCert(t, "server-rotated", newCA, 11)
+	writeFile(t, f.cfg.TLSCertFile, rotated.certPEM)
+	writeFile(t, f.cfg.TLSKeyFile, rotated.keyPEM)
+	future := time.Now().Add(time.Minute)
+	assert.NoError(os.Chtimes(f.cfg.TLSCertFile, future, future))
+	assert.NoError(os.Chtimes(f.cfg.TLSKeyFile, future, future))
+
+	assert.Eventually(func() bool {
+		_, err := get(newTestClient(newCA, nil), url+"/whoami")
+		return err == nil
+	}, 5*time.Second, 100*time.Millisecond, "the rotated certificate is served without restart")
+
+	_, err := get(newTestClient(f.ca, nil), url+"/whoami")
+	assert.Error(err, "the previous certificate is no longer served")
+}
+
+func Test_TLS_InvalidRotationKeepsCertificate(t *testing.T) {
+	f := newTLSFixture(t)
+	reloader, err := newCertReloader(f.cfg.TLSCertFile, f.cfg.TLSKeyFile, "", logger.NewNopLogger())
+	require.NoError(t, err)
+	before, _ := reloader.getCertificate(nil)
+
+	writeFile(t, f.cfg.TLSCertFile, []byte("not a certificate"))
+	future := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(f.cfg.TLSCertFile, future, future))
+	reloader.lastCheck = time.Time{}
+
+	after, _ := reloader.getCertificate(nil)
+	assert.Same(t, before, after)
+}
@@ -0,0 +1,107 @@
+// Package i18n localizes what the API writes for people: number words and error messages.
+// Message catalogs are embedded JSON files mapping English messages to their translation,
+// messages missing from a catalog are left in English.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Locale is a language, identified by its primary language subtag
+type Locale string
+
+const (
+	English Locale = "en"
+	French  Locale = "fr"
+	Spanish Locale = "es"
+)
+
+// Supported lists the locales of the API, English being the fallback
+var Supported = []Locale{English, French, Spanish}
+
+//go:embed catalogs/*.json
+var catalogFiles embed.FS
+
+// Translations of English messages by locale
+var catalogs = loadCatalogs()
+
+func loadCatalogs() map[Locale]map[string]string {
+	files, err := catalogFiles.ReadDir("catalogs")
+	if err != nil {
+		panic(err)
+	}
+	catalogs := map[Locale]map[string]string{}
+	for _, file := range files {
+		data, err := catalogFiles.ReadFile(path.Join("catalogs", file.Name()))
+		if err != nil {
+			panic(err)
+		}
+		var catalog map[string]string
+		if err := json.Unmarshal(data, &catalog); err != nil {
+			panic("invalid catalog " + file.Name() + ": " + err.Error())
+		}
+		catalogs[Locale(strings.TrimSuffix(file.Name(), ".json"))] = catalog
+	}
+	return catalogs
+}
+
+// Negotiate returns the supported locale with the highest q-value in an Accept-Language header, the first listed
+// on ties. Regional variants match their language (fr-CA is French), English is returned when nothing matches.
+func Negotiate(acceptLanguage string) Locale {
+	best, bestQ := English, 0.0
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if name, value, ok := strings.Cut(strings.TrimSpace(params), "="); ok && strings.TrimSpace(name) == "q" {
+			if parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+				q = parsed
+			}
+		}
+		language, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if locale := Locale(language); q > bestQ && slices.Contains(Supported, locale) {
+			best, bestQ = locale, q
+		}
+	}
+	return best
+}
+
+// Translate returns the translation of an English message, or the message when it has none
+func Translate(locale Locale, message string) string {
+	if translation, ok := catalogs[locale][message]; ok {
+		return translation
+	}
+	return message
+}
+
+// TranslateError returns the message of err in the locale. The first error of its chain having a translation,
+// breadth first, is translated within the message, details added by wrapping errors being kept as is.
+func TranslateError(locale Locale, err error) string {
+	message := err.Error()
+	catalog := catalogs[locale]
+	if catalog == nil {
+		return message
+	}
+
+	queue := []error{err}
+	for len(queue) > 0 {
+		e := queue[0]
+		queue = queue[1:]
+		if translation, ok := catalog[e.Error()]; ok {
+			return strings.Replace(message, e.Error(), translation, 1)
+		}
+		switch wrapper := e.(type) {
+		case interface{ Unwrap() error }:
+			if inner := wrapper.Unwrap(); inner != nil {
+				queue = append(queue, inner)
+			}
+		case interface{ Unwrap() []error }:
+			queue = append(queue, wrapper.Unwrap()...)
+		}
+	}
+	return message
+}
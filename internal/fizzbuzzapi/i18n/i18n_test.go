@@ -0,0 +1,61 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Negotiate(t *testing.T) {
+	tests := []struct {
+		header   string
+		expected Locale
+	}{
+		{"", English},
+		{"fr", French},
+		{"fr-CA", French},
+		{"ES-mx", Spanish},
+		{"de, es;q=0.8, fr;q=0.5", Spanish},
+		{"en;q=0.3, fr;q=0.7", French},
+		{"es, fr", Spanish},
+		{"de-DE, it", English},
+		{"*", English},
+		{"fr;q=0", English},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, Negotiate(tt.header), tt.header)
+	}
+}
+
+func Test_TranslateError(t *testing.T) {
+	assert := assert.New(t)
+	errTemplate := errors.New("invalid template")
+
+	assert.Equal("modèle invalide", TranslateError(French, errTemplate))
+	assert.Equal("invalid template", TranslateError(English, errTemplate))
+	assert.Equal("invalid template", TranslateError("de", errTemplate))
+
+	wrapped := fmt.Errorf("%w %q: unmatched } at offset 4", errTemplate, "fizz}")
+	assert.Equal(`plantilla no válida "fizz}": unmatched } at offset 4`, TranslateError(Spanish, wrapped))
+
+	joined := errors.Join(errors.New("no translation"), wrapped)
+	assert.Equal("no translation\nmodèle invalide \"fizz}\": unmatched } at offset 4", TranslateError(French, joined))
+
+	assert.Equal("unknown", TranslateError(French, errors.New("unknown")))
+}
+
+func Test_CatalogsComplete(t *testing.T) {
+	for _, locale := range Supported {
+		if locale == English {
+			continue
+		}
+		for message := range catalogs[French] {
+			assert.Contains(t, catalogs[locale], message, "%s lacks a translation", locale)
+		}
+		for message := range catalogs[locale] {
+			assert.Contains(t, catalogs[French], message, "%s translates a message French does not", locale)
+		}
+	}
+}
@@ -0,0 +1,284 @@
+package i18n
+
+import "strings"
+
+// Upper bound of the length of a spelled-out group of three digits with its scale, in any locale,
+// e.g. "quatre cent quatre-vingt-dix-sept milliards " or "cuatrocientos cincuenta y siete trillones "
+const maxWordsGroupLength = 48
+
+// NumberWords spells out an integer in the locale, in English for unsupported locales
+func NumberWords(locale Locale, i int) string {
+	locale = wordsLocale(locale)
+	var words string
+	switch locale {
+	case French:
+		words = frenchWords(absUint(i))
+	case Spanish:
+		words = spanishWords(absUint(i))
+	default:
+		words = englishWords(absUint(i))
+	}
+	if i < 0 {
+		return minusWords[locale] + " " + words
+	}
+	return words
+}
+
+// MaxNumberWordsLength returns an upper bound of the length in bytes of integers from lo to hi spelled out in the locale.
+// The bound is exact below 1000, and grows with the number of groups of three digits above.
+func MaxNumberWordsLength(locale Locale, lo, hi int) int {
+	locale = wordsLocale(locale)
+	length := 0
+	if lo < 0 {
+		length = len(minusWords[locale]) + 1
+	}
+	u := max(absUint(lo), absUint(hi))
+	if u < 1000 {
+		return length + smallWordsLengths[locale][u]
+	}
+	return length + maxWordsGroupLength*len(digitGroups(u))
+}
+
+// wordsLocale returns the locale numbers are spelled out in
+func wordsLocale(locale Locale) Locale {
+	if _, ok := minusWords[locale]; ok {
+		return locale
+	}
+	return English
+}
+
+// Longest spelled-out integer from 0 to n, for n below 1000, by locale
+var smallWordsLengths = func() map[Locale][]int {
+	lengths := map[Locale][]int{}
+	for locale := range minusWords {
+		lengths[locale] = make([]int, 1000)
+		longest := 0
+		for n := range 1000 {
+			longest = max(longest, len(NumberWords(locale, n)))
+			lengths[locale][n] = longest
+		}
+	}
+	return lengths
+}()
+
+var minusWords = map[Locale]string{English: "minus", French: "moins", Spanish: "menos"}
+
+var (
+	englishSmall  = []string{"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine", "ten", "eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen", "nineteen"}
+	englishTens   = []string{"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety"}
+	englishScales = []string{"", "thousand", "million", "billion", "trillion", "quadrillion", "quintillion"}
+)
+
+// englishWords spells out an integer in short scale: "one hundred twenty-three thousand four hundred fifty-six"
+func englishWords(u uint64) string {
+	if u == 0 {
+		return englishSmall[0]
+	}
+	groups := digitGroups(u)
+	var words []string
+	for g, group := range groups {
+		if group == 0 {
+			continue
+		}
+		words = append(words, englishHundreds(int(group)))
+		if scale := len(groups) - 1 - g; scale > 0 {
+			words = append(words, englishScales[scale])
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// englishHundreds spells out an integer from 1 to 999
+func englishHundreds(n int) string {
+	var words []string
+	if n >= 100 {
+		words = append(words, englishSmall[n/100], "hundred")
+		n %= 100
+	}
+	switch {
+	case n == 0:
+	case n < 20:
+		words = append(words, englishSmall[n])
+	case n%10 == 0:
+		words = append(words, englishTens[n/10])
+	default:
+		words = append(words, englishTens[n/10]+"-"+englishSmall[n%10])
+	}
+	return strings.Join(words, " ")
+}
+
+var (
+	frenchSmall  = []string{"zéro", "un", "deux", "trois", "quatre", "cinq", "six", "sept", "huit", "neuf", "dix", "onze", "douze", "treize", "quatorze", "quinze", "seize"}
+	frenchTens   = []string{"", "dix", "vingt", "trente", "quarante", "cinquante", "soixante"}
+	frenchScales = []string{"", "mille", "million", "milliard", "billion", "billiard", "trillion"}
+)
+
+// frenchWords spells out an integer in traditional spelling and long scale: "deux cent quatre-vingt mille un"
+func frenchWords(u uint64) string {
+	if u == 0 {
+		return frenchSmall[0]
+	}
+	groups := digitGroups(u)
+	var words []string
+	for g, group := range groups {
+		n := int(group)
+		switch scale := len(groups) - 1 - g; {
+		case n == 0:
+		case scale == 0:
+			words = append(words, frenchHundreds(n, true))
+		case scale == 1 && n == 1:
+			words = append(words, "mille")
+		case scale == 1:
+			// Mille is not a noun, vingt and cent stay singular before it
+			words = append(words, frenchHundreds(n, false), "mille")
+		case n == 1:
+			words = append(words, "un", frenchScales[scale])
+		default:
+			words = append(words, frenchHundreds(n, true), frenchScales[scale]+"s")
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// frenchHundreds spells out an integer from 1 to 999. Vingt and cent take an s when they end a plural number,
+// which plural tells whether they may.
+func frenchHundreds(n int, plural bool) string {
+	var words []string
+	hundreds, rest := n/100, n%100
+	switch {
+	case hundreds == 1:
+		words = append(words, "cent")
+	case hundreds > 1 && rest == 0 && plural:
+		words = append(words, frenchSmall[hundreds], "cents")
+	case hundreds > 1:
+		words = append(words, frenchSmall[hundreds], "cent")
+	}
+	if rest > 0 {
+		words = append(words, frenchTensWords(rest, plural))
+	}
+	return strings.Join(words, " ")
+}
+
+// frenchTensWords spells out an integer from 1 to 99: 70 to 79 and 90 to 99 count from 60 and 80
+func frenchTensWords(n int, plural bool) string {
+	tens, units := n/10, n%10
+	switch {
+	case n <= 16:
+		return frenchSmall[n]
+	case n < 20:
+		return "dix-" + frenchSmall[units]
+	case n < 70 && units == 0:
+		return frenchTens[tens]
+	case n < 70 && units == 1:
+		return frenchTens[tens] + " et un"
+	case n < 70:
+		return frenchTens[tens] + "-" + frenchSmall[units]
+	case n == 71:
+		return "soixante et onze"
+	case n < 80:
+		return "soixante-" + frenchTensWords(n-60, plural)
+	case n == 80 && plural:
+		return "quatre-vingts"
+	case n == 80:
+		return "quatre-vingt"
+	default:
+		return "quatre-vingt-" + frenchTensWords(n-80, plural)
+	}
+}
+
+var (
+	spanishSmall = []string{
+		"cero", "uno", "dos", "tres", "cuatro", "cinco", "seis", "siete", "ocho", "nueve",
+		"diez", "once", "doce", "trece", "catorce", "quince", "dieciséis", "diecisiete", "dieciocho", "diecinueve",
+		"veinte", "veintiuno", "veintidós", "veintitrés", "veinticuatro", "veinticinco", "veintiséis", "veintisiete", "veintiocho", "veintinueve",
+	}
+	spanishTens     = []string{"", "", "", "treinta", "cuarenta", "cincuenta", "sesenta", "setenta", "ochenta", "noventa"}
+	spanishHundreds = []string{"", "ciento", "doscientos", "trescientos", "cuatrocientos", "quinientos", "seiscientos", "setecientos", "ochocientos", "novecientos"}
+	spanishScales   = [][2]string{{"", ""}, {"millón", "millones"}, {"billón", "billones"}, {"trillón", "trillones"}}
+)
+
+// spanishWords spells out an integer in long scale, by groups of six digits: "dos millones veintiún mil uno"
+func spanishWords(u uint64) string {
+	if u == 0 {
+		return spanishSmall[0]
+	}
+	var groups []int
+	for ; u > 0; u /= 1_000_000 {
+		groups = append([]int{int(u % 1_000_000)}, groups...)
+	}
+	var words []string
+	for g, n := range groups {
+		switch scale := len(groups) - 1 - g; {
+		case n == 0:
+		case scale == 0:
+			words = append(words, spanishThousands(n, false))
+		case n == 1:
+			words = append(words, "un", spanishScales[scale][0])
+		default:
+			words = append(words, spanishThousands(n, true), spanishScales[scale][1])
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// spanishThousands spells out an integer from 1 to 999999. Uno becomes un before a noun, which apocope tells.
+func spanishThousands(n int, apocope bool) string {
+	var words []string
+	thousands, rest := n/1000, n%1000
+	switch {
+	case thousands == 1:
+		words = append(words, "mil")
+	case thousands > 1:
+		words = append(words, spanishHundredWords(thousands, true), "mil")
+	}
+	if rest > 0 {
+		words = append(words, spanishHundredWords(rest, apocope))
+	}
+	return strings.Join(words, " ")
+}
+
+// spanishHundredWords spells out an integer from 1 to 999
+func spanishHundredWords(n int, apocope bool) string {
+	var words []string
+	hundreds, rest := n/100, n%100
+	switch {
+	case hundreds == 1 && rest == 0:
+		words = append(words, "cien")
+	case hundreds > 0:
+		words = append(words, spanishHundreds[hundreds])
+	}
+	tens, units := rest/10, rest%10
+	switch {
+	case rest == 0:
+	case rest == 1 && apocope:
+		words = append(words, "un")
+	case rest == 21 && apocope:
+		words = append(words, "veintiún")
+	case rest < 30:
+		words = append(words, spanishSmall[rest])
+	case units == 0:
+		words = append(words, spanishTens[tens])
+	case units == 1 && apocope:
+		words = append(words, spanishTens[tens], "y", "un")
+	default:
+		words = append(words, spanishTens[tens], "y", spanishSmall[units])
+	}
+	return strings.Join(words, " ")
+}
+
+// digitGroups splits an integer in groups of three digits, most significant first
+func digitGroups(u uint64) []uint64 {
+	groups := []uint64{u % 1000}
+	for u /= 1000; u > 0; u /= 1000 {
+		groups = append([]uint64{u % 1000}, groups...)
+	}
+	return groups
+}
+
+// absUint returns the absolute value of i, which does not overflow for the smallest int
+func absUint(i int) uint64 {
+	if i < 0 {
+		return -uint64(i)
+	}
+	return uint64(i)
+}
@@ -0,0 +1,87 @@
+package i18n
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NumberWords(t *testing.T) {
+	tests := []struct {
+		locale   Locale
+		i        int
+		expected string
+	}{
+		{English, 0, "zero"},
+		{English, -1042, "minus one thousand forty-two"},
+		{English, 1000000, "one million"},
+		{"de", 21, "twenty-one"},
+		{"de", -1, "minus one"},
+
+		{French, 0, "zéro"},
+		{French, 17, "dix-sept"},
+		{French, 21, "vingt et un"},
+		{French, 22, "vingt-deux"},
+		{French, 71, "soixante et onze"},
+		{French, 77, "soixante-dix-sept"},
+		{French, 80, "quatre-vingts"},
+		{French, 81, "quatre-vingt-un"},
+		{French, 91, "quatre-vingt-onze"},
+		{French, 100, "cent"},
+		{French, 101, "cent un"},
+		{French, 200, "deux cents"},
+		{French, 280, "deux cent quatre-vingts"},
+		{French, 1000, "mille"},
+		{French, 2000, "deux mille"},
+		{French, 80000, "quatre-vingt mille"},
+		{French, 200000, "deux cent mille"},
+		{French, 280001, "deux cent quatre-vingt mille un"},
+		{French, 1000000, "un million"},
+		{French, 200000000, "deux cents millions"},
+		{French, 2000000000, "deux milliards"},
+		{French, -5, "moins cinq"},
+
+		{Spanish, 0, "cero"},
+		{Spanish, 16, "dieciséis"},
+		{Spanish, 21, "veintiuno"},
+		{Spanish, 31, "treinta y uno"},
+		{Spanish, 100, "cien"},
+		{Spanish, 101, "ciento uno"},
+		{Spanish, 500, "quinientos"},
+		{Spanish, 1000, "mil"},
+		{Spanish, 21000, "veintiún mil"},
+		{Spanish, 31000, "treinta y un mil"},
+		{Spanish, 100000, "cien mil"},
+		{Spanish, 1000000, "un millón"},
+		{Spanish, 2021001, "dos millones veintiún mil uno"},
+		{Spanish, 1000000000000, "un billón"},
+		{Spanish, -7, "menos siete"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, NumberWords(tt.locale, tt.i), "%d in %s", tt.i, tt.locale)
+	}
+}
+
+func Test_MaxNumberWordsLength(t *testing.T) {
+	samples := []int{math.MinInt, math.MaxInt}
+	for i := -3000; i <= 3000; i++ {
+		samples = append(samples, i)
+	}
+	// Values made of the longest groups, in every scale
+	for _, group := range []int{777, 797, 373, 977, 999, 371, 871, 473} {
+		for n := group; n > 0 && n < math.MaxInt/1000; n = n*1000 + group {
+			samples = append(samples, n, -n)
+		}
+	}
+	for _, locale := range Supported {
+		for _, i := range samples {
+			assert.LessOrEqual(t, len(NumberWords(locale, i)), MaxNumberWordsLength(locale, i, i), "%d in %s", i, locale)
+		}
+	}
+
+	// Exact below 1000
+	assert.Equal(t, len("three"), MaxNumberWordsLength(English, 1, 4))
+	assert.Equal(t, len("quatre"), MaxNumberWordsLength(French, 1, 4))
+	assert.Equal(t, len("menos cuatro"), MaxNumberWordsLength(Spanish, -4, 2))
+}
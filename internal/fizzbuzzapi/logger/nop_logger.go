@@ -0,0 +1,12 @@
+package logger
+
+// NopLogger discards every message, for tools where server logs would only be noise
+type NopLogger struct{}
+
+func NewNopLogger() NopLogger {
+	return NopLogger{}
+}
+
+func (NopLogger) Info(msg string, keysAndValues ...any)  {}
+func (NopLogger) Error(msg string, keysAndValues ...any) {}
+func (NopLogger) Debug(msg string, keysAndValues ...any) {}
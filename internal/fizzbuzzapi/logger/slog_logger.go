@@ -2,12 +2,22 @@ package logger
 
 import (
 	"log/slog"
+	"strings"
 )
 
 func NewSlogLogger() *slog.Logger {
 	logger := slog.Default()
 
-	// TODO: make log level configurable
 	slog.SetLogLoggerLevel(slog.LevelInfo)
 	return logger
 }
+
+// SetLevel changes the level of the slog logger, it can be called at any time: "debug", "info", "warn" or "error"
+func SetLevel(level string) error {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(strings.ToUpper(level))); err != nil {
+		return err
+	}
+	slog.SetLogLoggerLevel(l)
+	return nil
+}
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const APIKeyHeader = "X-API-Key"
+
+// Error codes of rejected admin requests
+const (
+	ErrCodeUnauthorized  = "unauthorized"
+	ErrCodeAdminDisabled = "admin_disabled"
+)
+
+// RequireAPIKey rejects requests whose X-API-Key header does not match key.
+// An empty key disables the routes it guards altogether.
+func RequireAPIKey(key string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if key == "" {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"code": ErrCodeAdminDisabled, "error": "admin API is disabled"})
+			return
+		}
+		if subtle.ConstantTimeCompare([]byte(c.GetHeader(APIKeyHeader)), []byte(key)) != 1 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"code": ErrCodeUnauthorized, "error": "invalid API key"})
+			return
+		}
+		c.Next()
+	}
+}
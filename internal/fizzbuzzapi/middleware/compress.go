@@ -0,0 +1,276 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Content codings supported for responses and request bodies
+const (
+	EncodingGzip = "gzip"
+	EncodingZstd = "zstd"
+)
+
+var SupportedEncodings = []string{EncodingZstd, EncodingGzip}
+
+// Error codes of request bodies in an unsupported content coding, or failing to decode
+const (
+	ErrCodeUnsupportedEncoding = "unsupported_encoding"
+	errCodeInvalidRequest      = "invalid_request" // Code of malformed requests, as recorded by the handlers
+)
+
+// compressor writes a content coding, and is reused through a pool once closed
+type compressor interface {
+	io.WriteCloser
+	Flush() error
+	Reset(w io.Writer)
+}
+
+var compressors = map[string]*sync.Pool{
+	EncodingGzip: {New: func() any {
+		gw, _ := gzip.NewWriterLevel(nil, gzip.DefaultCompression)
+		return gw
+	}},
+	EncodingZstd: {New: func() any {
+		// Encoders run on the request goroutine, concurrency comes from concurrent requests
+		zw, _ := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedDefault), zstd.WithEncoderConcurrency(1))
+		return zw
+	}},
+}
+
+// Content types already compressed, that are not worth compressing again
+var incompressibleTypes = []string{"image/", "video/", "audio/", "application/gzip", "application/zstd", "application/zip"}
+
+// Compress compresses responses in the preferred coding of the Accept-Encoding header among encodings,
+// listed by server preference. Responses smaller than minSize are sent as is, unless flushed before
+// reaching it: streamed responses are compressed from their first flush.
+func Compress(encodings []string, minSize int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if len(encodings) == 0 {
+			c.Next()
+			return
+		}
+		c.Writer.Header().Add("Vary", "Accept-Encoding")
+		encoding := negotiateEncoding(c.GetHeader("Accept-Encoding"), encodings)
+		if encoding == "" || c.Request.Method == http.MethodHead {
+			c.Next()
+			return
+		}
+
+		w := &compressWriter{ResponseWriter: c.Writer, encoding: encoding, minSize: minSize}
+		c.Writer = w
+		defer func() {
+			w.Close()
+			c.Writer = w.ResponseWriter
+		}()
+		c.Next()
+	}
+}
+
+// negotiateEncoding returns the coding of encodings with the highest q-value in the Accept-Encoding header,
+// the first one listed on ties, or "" when the response must not be compressed
+func negotiateEncoding(accept string, encodings []string) string {
+	qs := map[string]float64{}
+	for _, part := range strings.Split(accept, ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if name, value, ok := strings.Cut(strings.TrimSpace(params), "="); ok && strings.TrimSpace(name) == "q" {
+			if parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+				q = parsed
+			}
+		}
+		if coding != "" {
+			qs[strings.ToLower(coding)] = q
+		}
+	}
+
+	best, bestQ := "", 0.0
+	for _, encoding := range encodings {
+		q, ok := qs[encoding]
+		if !ok {
+			q, ok = qs["*"]
+		}
+		if ok && q > bestQ {
+			best, bestQ = encoding, q
+		}
+	}
+	return best
+}
+
+// compressWriter buffers the beginning of the response until it is known to reach the minimum size,
+// then compresses the rest on the fly
+type compressWriter struct {
+	gin.ResponseWriter
+	encoding string
+	minSize  int
+
+	buf        bytes.Buffer
+	compressor compressor // Set once the response is known to be compressed
+	decided    bool
+}
+
+func (w *compressWriter) Write(b []byte) (int, error) {
+	if !w.decided {
+		w.buf.Write(b)
+		if w.buf.Len() < w.minSize {
+			return len(b), nil
+		}
+		if err := w.start(); err != nil {
+			return 0, err
+		}
+		return len(b), nil
+	}
+	if w.compressor != nil {
+		return w.compressor.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Written reports whether anything was written, buffered or not, for handlers checking whether they responded
+func (w *compressWriter) Written() bool {
+	return w.buf.Len() > 0 || w.ResponseWriter.Written()
+}
+
+// Flush sends what was written so far, compressed when the response is compressible whatever its size
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		if err := w.start(); err != nil {
+			return
+		}
+	}
+	if w.compressor != nil {
+		w.compressor.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// Close writes the end of the response and returns the compressor to its pool
+func (w *compressWriter) Close() error {
+	if !w.decided {
+		w.decided = true
+		if w.buf.Len() > 0 {
+			_, err := w.ResponseWriter.Write(w.buf.Bytes())
+			return err
+		}
+		return nil
+	}
+	if w.compressor == nil {
+		return nil
+	}
+	err := w.compressor.Close()
+	w.compressor.Reset(nil)
+	compressors[w.encoding].Put(w.compressor)
+	w.compressor = nil
+	return err
+}
+
+// Unwrap gives http.ResponseController access to the connection, e.g. to extend write deadlines
+func (w *compressWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// start decides whether the response is compressed, then writes the headers and the buffered bytes
+func (w *compressWriter) start() error {
+	w.decided = true
+	if w.compressible() {
+		header := w.Header()
+		header.Set("Content-Encoding", w.encoding)
+		header.Del("Content-Length")
+		// The compressed body is not byte for byte the one a strong ETag identifies
+		if etag := header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+			header.Set("ETag", "W/"+etag)
+		}
+		w.compressor = compressors[w.encoding].Get().(compressor)
+		w.compressor.Reset(w.ResponseWriter)
+	}
+
+	var err error
+	if w.buf.Len() > 0 {
+		if w.compressor != nil {
+			_, err = w.compressor.Write(w.buf.Bytes())
+		} else {
+			_, err = w.ResponseWriter.Write(w.buf.Bytes())
+		}
+	}
+	w.buf = bytes.Buffer{}
+	return err
+}
+
+func (w *compressWriter) compressible() bool {
+	if w.ResponseWriter.Written() || w.Header().Get("Content-Encoding") != "" {
+		return false
+	}
+	switch status := w.Status(); {
+	case status < 200, status == http.StatusNoContent, status == http.StatusNotModified:
+		return false
+	}
+	mediaType, _, _ := mime.ParseMediaType(w.Header().Get("Content-Type"))
+	return !slices.ContainsFunc(incompressibleTypes, func(prefix string) bool {
+		return strings.HasPrefix(mediaType, prefix)
+	})
+}
+
+// Decompress decodes request bodies sent with a gzip or zstd Content-Encoding, rejecting other codings with 415.
+// Decoded bodies larger than maxSize fail to read with an *http.MaxBytesError, protecting against decompression bombs.
+func Decompress(maxSize int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		encoding := strings.ToLower(strings.TrimSpace(c.GetHeader("Content-Encoding")))
+		if encoding == "" || encoding == "identity" {
+			c.Next()
+			return
+		}
+
+		var body io.ReadCloser
+		switch encoding {
+		case EncodingGzip:
+			gr, err := gzip.NewReader(c.Request.Body)
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"code": errCodeInvalidRequest, "error": "invalid gzip request body: " + err.Error()})
+				return
+			}
+			body = gr
+		case EncodingZstd:
+			zr, err := zstd.NewReader(c.Request.Body, zstd.WithDecoderConcurrency(1), zstd.WithDecoderMaxMemory(uint64(maxSize)))
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"code": errCodeInvalidRequest, "error": "invalid zstd request body: " + err.Error()})
+				return
+			}
+			body = zr.IOReadCloser()
+		default:
+			c.AbortWithStatusJSON(http.StatusUnsupportedMediaType, gin.H{
+				"code":  ErrCodeUnsupportedEncoding,
+				"error": "unsupported request Content-Encoding " + strconv.Quote(encoding) + ", expected one of " + strings.Join(SupportedEncodings, ", "),
+			})
+			return
+		}
+		defer body.Close()
+
+		c.Request.Body = http.MaxBytesReader(c.Writer, body, maxSize)
+		c.Request.Header.Del("Content-Encoding")
+		c.Request.Header.Del("Content-Length")
+		c.Request.ContentLength = -1
+		c.Next()
+	}
+}
+
+// LimitBody makes request bodies larger than maxSize, once decoded, fail to read with an *http.MaxBytesError
+func LimitBody(maxSize int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSize)
+		c.Next()
+	}
+}
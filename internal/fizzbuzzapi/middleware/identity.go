@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+const callerKey = "caller"
+
+// CallerIdentity records the subject of the verified TLS client certificate as the caller identity
+func CallerIdentity() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if state := c.Request.TLS; state != nil && len(state.VerifiedChains) > 0 && len(state.VerifiedChains[0]) > 0 {
+			c.Set(callerKey, state.VerifiedChains[0][0].Subject.String())
+		}
+		c.Next()
+	}
+}
+
+// Caller returns the identity of the caller, empty for anonymous callers
+func Caller(c *gin.Context) string {
+	return c.GetString(callerKey)
+}
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"fizzbuzz-api/internal/fizzbuzzapi/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+const localeKey = "locale"
+
+// Localize negotiates the locale of the response from the Accept-Language header.
+// Responses vary by the header, which caches must take into account.
+func Localize() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(localeKey, i18n.Negotiate(c.GetHeader("Accept-Language")))
+		c.Writer.Header().Add("Vary", "Accept-Language")
+		c.Next()
+	}
+}
+
+// Locale returns the locale negotiated by Localize, or negotiates it on routes Localize does not guard
+func Locale(c *gin.Context) i18n.Locale {
+	if locale, ok := c.Get(localeKey); ok {
+		return locale.(i18n.Locale)
+	}
+	return i18n.Negotiate(c.GetHeader("Accept-Language"))
+}
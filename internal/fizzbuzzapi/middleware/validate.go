@@ -0,0 +1,113 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/gin-gonic/gin"
+)
+
+var ginPathParam = regexp.MustCompile(`[:*](\w+)`)
+
+// ValidateRequest checks the query parameters and body of requests against the operation documented for their route.
+// Invalid requests are passed to reject, which is expected to respond; routes missing from the document are not checked.
+func ValidateRequest(doc *openapi3.T, reject func(c *gin.Context, err error)) gin.HandlerFunc {
+	return validateRequest(doc, reject, &openapi3filter.Options{
+		AuthenticationFunc: openapi3filter.NoopAuthenticationFunc, // API keys are checked by RequireAPIKey
+	})
+}
+
+// ValidateQuery checks requests like ValidateRequest, but leaves their body unread for handlers consuming it
+// as it is read, which would otherwise be read whole beforehand
+func ValidateQuery(doc *openapi3.T, reject func(c *gin.Context, err error)) gin.HandlerFunc {
+	return validateRequest(doc, reject, &openapi3filter.Options{
+		AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+		ExcludeRequestBody: true,
+	})
+}
+
+func validateRequest(doc *openapi3.T, reject func(c *gin.Context, err error), options *openapi3filter.Options) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		path := ginPathParam.ReplaceAllString(c.FullPath(), "{$1}")
+		item := doc.Paths.Value(path)
+		if item == nil || item.GetOperation(c.Request.Method) == nil {
+			c.Next()
+			return
+		}
+
+		input := &openapi3filter.RequestValidationInput{
+			Request:    c.Request,
+			PathParams: pathParams(c),
+			Route: &routers.Route{
+				Spec:      doc,
+				Path:      path,
+				PathItem:  item,
+				Method:    c.Request.Method,
+				Operation: item.GetOperation(c.Request.Method),
+			},
+			Options: options,
+		}
+		if err := openapi3filter.ValidateRequest(c.Request.Context(), input); err != nil {
+			reject(c, validationError(err))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+func pathParams(c *gin.Context) map[string]string {
+	params := make(map[string]string, len(c.Params))
+	for _, p := range c.Params {
+		params[p.Key] = strings.TrimPrefix(p.Value, "/")
+	}
+	return params
+}
+
+// validationError shortens the errors of openapi3filter, which embed the whole schema, to the failing field and reason
+func validationError(err error) error {
+	var reqErr *openapi3filter.RequestError
+	if !errors.As(err, &reqErr) {
+		return err
+	}
+
+	var where string
+	switch {
+	case reqErr.Parameter != nil:
+		where = fmt.Sprintf("parameter %q", reqErr.Parameter.Name)
+	case reqErr.RequestBody != nil:
+		where = "request body"
+	default:
+		return errors.New(reqErr.Error())
+	}
+
+	var schemaErr *openapi3.SchemaError
+	if errors.As(reqErr.Err, &schemaErr) {
+		reason := schemaErr.Reason
+		// A value matching none of the alternatives fails for the reason of each alternative
+		var alternatives openapi3.MultiError
+		if schemaErr.SchemaField == "oneOf" && errors.As(schemaErr.Origin, &alternatives) {
+			reasons := make([]string, 0, len(alternatives))
+			for _, err := range alternatives {
+				var altErr *openapi3.SchemaError
+				if errors.As(err, &altErr) {
+					reasons = append(reasons, altErr.Reason)
+				}
+			}
+			reason = strings.Join(reasons, ", or ")
+		}
+		if field := strings.Join(schemaErr.JSONPointer(), "."); field != "" {
+			return fmt.Errorf("invalid %s: %s: %s", where, field, reason)
+		}
+		return fmt.Errorf("invalid %s: %s", where, reason)
+	}
+	if reqErr.Err != nil {
+		return fmt.Errorf("invalid %s: %w", where, reqErr.Err) // Keeps read errors such as *http.MaxBytesError
+	}
+	return fmt.Errorf("invalid %s: %s", where, reqErr.Reason)
+}
@@ -0,0 +1,61 @@
+package openapi
+
+import (
+	"cmp"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fizzbuzz-api/internal/fizzbuzzapi/types"
+	"fmt"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// The OpenAPI document of the REST API. Routes and types must be kept in sync with it, see the drift test of the http package.
+//
+//go:embed openapi.json
+var document []byte
+
+// Page rendering the document served at /openapi.json
+//
+//go:embed docs.html
+var DocsPage []byte
+
+// Load parses and validates the embedded document
+func Load() (*openapi3.T, error) {
+	doc, err := openapi3.NewLoader().LoadFromData(document)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load OpenAPI document: %w", err)
+	}
+	if err := doc.Validate(context.Background()); err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI document: %w", err)
+	}
+	return doc, nil
+}
+
+// Document returns the embedded document with the server version, and the configured limits as schema maxima.
+// Strings may be as long as the longest of plain strings and templates, counted in the unit set by x-length-unit:
+// maxLength alone would count code points.
+func Document(limits types.FizzBuzzLimits, version string) ([]byte, error) {
+	var doc map[string]any
+	if err := json.Unmarshal(document, &doc); err != nil {
+		return nil, err
+	}
+
+	doc["info"].(map[string]any)["version"] = version
+
+	request := schemaProperties(doc, "FizzBuzzRequest")
+	request["limit"].(map[string]any)["maximum"] = limits.MaxLimit
+	for _, name := range []string{"str1", "str2"} {
+		str := request[name].(map[string]any)
+		str["maxLength"] = max(limits.MaxStringLength, limits.MaxTemplateLength)
+		str["x-length-unit"] = cmp.Or(limits.StringLengthUnit, "bytes")
+	}
+
+	return json.Marshal(doc)
+}
+
+func schemaProperties(doc map[string]any, schema string) map[string]any {
+	schemas := doc["components"].(map[string]any)["schemas"].(map[string]any)
+	return schemas[schema].(map[string]any)["properties"].(map[string]any)
+}
@@ -0,0 +1,137 @@
+package reload
+
+import (
+	"fizzbuzz-api/internal/fizzbuzzapi/config"
+	"fizzbuzz-api/internal/fizzbuzzapi/logger"
+	"fizzbuzz-api/internal/fizzbuzzapi/types"
+	"os"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	TriggerSignal = "signal"
+	TriggerFile   = "file"
+
+	StatusApplied   = "applied"
+	StatusUnchanged = "unchanged"
+	StatusRejected  = "rejected"
+)
+
+// Number of reload attempts kept in the history
+const historySize = 20
+
+// LoadFunc loads and validates the config from the same sources as at startup
+type LoadFunc func() (*config.Config, error)
+
+// ApplyFunc swaps the reloadable settings of the running server
+type ApplyFunc func(cfg *config.Config)
+
+// Reloader reloads the config on demand, applying only the settings listed in config.ReloadableFields
+type Reloader struct {
+	load  LoadFunc
+	apply ApplyFunc
+	log   logger.Logger
+
+	mu      sync.Mutex
+	current config.Config
+	history []types.ConfigReload
+}
+
+func NewReloader(current *config.Config, load LoadFunc, apply ApplyFunc, log logger.Logger) *Reloader {
+	return &Reloader{
+		load:    load,
+		apply:   apply,
+		log:     log,
+		current: *current,
+	}
+}
+
+// Reload loads the config and applies its reloadable settings. Invalid configs are rejected and leave the server untouched.
+func (r *Reloader) Reload(trigger string) types.ConfigReload {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	event := types.ConfigReload{Time: time.Now(), Trigger: trigger}
+	loaded, err := r.load()
+	if err != nil {
+		event.Status = StatusRejected
+		event.Error = err.Error()
+		r.log.Error("config reload rejected", "trigger", trigger, "error", err)
+		r.record(event)
+		return event
+	}
+
+	next := r.current
+	for _, f := range config.Fields() {
+		if loaded.Get(f.Name) == r.current.Get(f.Name) {
+			continue
+		}
+		if slices.Contains(config.ReloadableFields, f.Name) {
+			next.Set(f.Name, loaded.Get(f.Name))
+			event.Applied = append(event.Applied, f.Name)
+		} else {
+			event.Ignored = append(event.Ignored, f.Name)
+		}
+	}
+
+	event.Status = StatusUnchanged
+	if len(event.Applied) > 0 {
+		event.Status = StatusApplied
+		r.apply(&next)
+		r.current = next
+	}
+	r.log.Info("config reloaded", "trigger", trigger, "status", event.Status, "applied", event.Applied, "ignored", event.Ignored)
+	if len(event.Ignored) > 0 {
+		r.log.Info("config changes require a restart", "settings", event.Ignored)
+	}
+	r.record(event)
+	return event
+}
+
+func (r *Reloader) record(event types.ConfigReload) {
+	r.history = append(r.history, event)
+	if len(r.history) > historySize {
+		r.history = r.history[len(r.history)-historySize:]
+	}
+}
+
+// History returns the last reload attempts, oldest first. A nil reloader has no history.
+func (r *Reloader) History() []types.ConfigReload {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.history)
+}
+
+// WatchFile reloads the config whenever the modification time of path changes, until stop is closed
+func (r *Reloader) WatchFile(path string, interval time.Duration, stop <-chan struct{}) {
+	lastMod := modTime(path)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			mod := modTime(path)
+			if mod.Equal(lastMod) {
+				continue
+			}
+			lastMod = mod
+			r.Reload(TriggerFile)
+		case <-stop:
+			return
+		}
+	}
+}
+
+func modTime(path string) time.Time {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
@@ -0,0 +1,74 @@
+package reload
+
+import (
+	"errors"
+	"fizzbuzz-api/internal/fizzbuzzapi/config"
+	"fizzbuzz-api/internal/fizzbuzzapi/logger"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestReloader(current config.Config, loaded *config.Config, loadErr error, applied *[]config.Config) *Reloader {
+	return NewReloader(&current,
+		func() (*config.Config, error) { return loaded, loadErr },
+		func(cfg *config.Config) { *applied = append(*applied, *cfg) },
+		logger.NewNopLogger(),
+	)
+}
+
+func Test_Reload_AppliesReloadableFields(t *testing.T) {
+	assert := assert.New(t)
+	current := config.Config{Port: "4255", MaxFizzBuzzLimit: 100, MaxStringLength: 10, LogLevel: "info"}
+	loaded := current
+	loaded.MaxFizzBuzzLimit = 500
+	loaded.Port = "9090"
+
+	var applied []config.Config
+	r := newTestReloader(current, &loaded, nil, &applied)
+	event := r.Reload(TriggerSignal)
+
+	assert.Equal(StatusApplied, event.Status)
+	assert.Equal([]string{"MAX_FIZZBUZZ_LIMIT"}, event.Applied)
+	assert.Equal([]string{"PORT"}, event.Ignored)
+	assert.Len(applied, 1)
+	assert.Equal(500, applied[0].MaxFizzBuzzLimit)
+	assert.Equal("4255", applied[0].Port, "settings requiring a restart are not applied")
+}
+
+func Test_Reload_Unchanged(t *testing.T) {
+	assert := assert.New(t)
+	current := config.Config{Port: "4255", MaxFizzBuzzLimit: 100}
+	loaded := current
+
+	var applied []config.Config
+	r := newTestReloader(current, &loaded, nil, &applied)
+	event := r.Reload(TriggerFile)
+
+	assert.Equal(StatusUnchanged, event.Status)
+	assert.Empty(applied)
+}
+
+func Test_Reload_Rejected(t *testing.T) {
+	assert := assert.New(t)
+	var applied []config.Config
+	r := newTestReloader(config.Config{}, nil, errors.New("config.yaml:2: MAX_STRING_LENGTH: must be strictly positive"), &applied)
+	event := r.Reload(TriggerSignal)
+
+	assert.Equal(StatusRejected, event.Status)
+	assert.Contains(event.Error, "config.yaml:2")
+	assert.Empty(applied)
+	assert.Len(r.History(), 1)
+}
+
+func Test_History_Bounded(t *testing.T) {
+	var applied []config.Config
+	r := newTestReloader(config.Config{}, &config.Config{}, nil, &applied)
+	for range historySize + 5 {
+		r.Reload(TriggerSignal)
+	}
+	assert.Len(t, r.History(), historySize)
+
+	var nilReloader *Reloader
+	assert.Empty(t, nilReloader.History())
+}
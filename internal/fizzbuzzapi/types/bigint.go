@@ -0,0 +1,79 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ugorji/go/codec"
+)
+
+// BigInt is an arbitrary-precision integer for positions beyond int64. It is written in JSON as a decimal string,
+// and read from a decimal string or a JSON number.
+type BigInt struct {
+	big.Int
+}
+
+// Maximum number of digits of a parsed BigInt. Arithmetic and formatting get slower with the number of digits,
+// unbounded ones would let a single request hold the CPU.
+const MaxBigIntDigits = 1000
+
+var ErrTooManyDigits = errors.New("integer has too many digits")
+
+func NewBigInt(x int64) BigInt {
+	var b BigInt
+	b.SetInt64(x)
+	return b
+}
+
+// ParseBigInt parses a base 10 integer of at most MaxBigIntDigits digits
+func ParseBigInt(s string) (BigInt, error) {
+	if digits := len(strings.TrimLeft(s, "+-")); digits > MaxBigIntDigits {
+		return BigInt{}, fmt.Errorf("%w: %d digits, expected at most %d", ErrTooManyDigits, digits, MaxBigIntDigits)
+	}
+	var b BigInt
+	if _, ok := b.SetString(s, 10); !ok {
+		return BigInt{}, fmt.Errorf("invalid integer %q", s)
+	}
+	return b, nil
+}
+
+// UnmarshalParam reads query parameters, see gin's binding.BindUnmarshaler
+func (b *BigInt) UnmarshalParam(param string) error {
+	parsed, err := ParseBigInt(param)
+	if err != nil {
+		return err
+	}
+	*b = parsed
+	return nil
+}
+
+func (b *BigInt) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	if s, ok := bytes.CutPrefix(data, []byte(`"`)); ok {
+		return b.UnmarshalParam(string(bytes.TrimSuffix(s, []byte(`"`))))
+	}
+	return b.UnmarshalParam(string(data))
+}
+
+func (b BigInt) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.String())
+}
+
+// CodecEncodeSelf writes MessagePack as a decimal string too, like JSON
+func (b *BigInt) CodecEncodeSelf(e *codec.Encoder) {
+	e.MustEncode(b.String())
+}
+
+func (b *BigInt) CodecDecodeSelf(d *codec.Decoder) {
+	var s string
+	d.MustDecode(&s)
+	if err := b.UnmarshalParam(s); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,56 @@
+package types
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ugorji/go/codec"
+)
+
+func Test_BigInt_JSON(t *testing.T) {
+	assert := assert.New(t)
+
+	var v struct {
+		N BigInt `json:"n"`
+	}
+	assert.NoError(json.Unmarshal([]byte(`{"n":"1000000000000000000000000000000"}`), &v))
+	assert.Equal("1000000000000000000000000000000", v.N.String())
+	assert.NoError(json.Unmarshal([]byte(`{"n":12}`), &v), "JSON numbers are accepted too")
+	assert.Equal(int64(12), v.N.Int64())
+	assert.Error(json.Unmarshal([]byte(`{"n":"1e30"}`), &v))
+
+	b, err := json.Marshal(v)
+	assert.NoError(err)
+	assert.Equal(`{"n":"12"}`, string(b))
+}
+
+func Test_ParseBigInt_MaxDigits(t *testing.T) {
+	assert := assert.New(t)
+	_, err := ParseBigInt(strings.Repeat("9", MaxBigIntDigits))
+	assert.NoError(err)
+	_, err = ParseBigInt("-" + strings.Repeat("9", MaxBigIntDigits))
+	assert.NoError(err)
+
+	_, err = ParseBigInt(strings.Repeat("9", MaxBigIntDigits+1))
+	assert.ErrorIs(err, ErrTooManyDigits)
+	var b BigInt
+	assert.ErrorIs(b.UnmarshalParam(strings.Repeat("0", MaxBigIntDigits+1)), ErrTooManyDigits)
+	assert.ErrorIs(json.Unmarshal([]byte(strings.Repeat("1", MaxBigIntDigits+1)), &b), ErrTooManyDigits)
+}
+
+func Test_BigInt_MessagePack(t *testing.T) {
+	assert := assert.New(t)
+	n, _ := ParseBigInt("-1000000000000000000000000000000")
+
+	var b []byte
+	assert.NoError(codec.NewEncoderBytes(&b, &codec.MsgpackHandle{}).Encode(&n))
+	var s string
+	assert.NoError(codec.NewDecoderBytes(b, &codec.MsgpackHandle{}).Decode(&s))
+	assert.Equal("-1000000000000000000000000000000", s)
+
+	var decoded BigInt
+	assert.NoError(codec.NewDecoderBytes(b, &codec.MsgpackHandle{}).Decode(&decoded))
+	assert.Equal(0, decoded.Cmp(&n.Int))
+}
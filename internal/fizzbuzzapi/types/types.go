@@ -1,21 +1,75 @@
 package types
 
+import (
+	"iter"
+	"strconv"
+	"time"
+)
+
 /*
 	Defines the types used in the FizzBuzz API that are shared across different packages.
 	Types that are only relevant to a specific package should be defined within that package.
 */
 
+// FizzBuzzRequest is the JSON body of generate requests, and the query of verify requests
 type FizzBuzzRequest struct {
-	Int1  int    `json:"int1" binding:"required"`
-	Int2  int    `json:"int2" binding:"required"`
-	Limit int    `json:"limit" binding:"required"`
-	Str1  string `json:"str1" binding:"required"`
-	Str2  string `json:"str2" binding:"required"`
+	Int1  int    `json:"int1" form:"int1" binding:"required"`
+	Int2  int    `json:"int2" form:"int2" binding:"required"`
+	Limit int    `json:"limit,omitempty" form:"limit" binding:"required_without=End"`
+	Start *int   `json:"start,omitempty" form:"start"` // A range replaces the limit: from start (1 by default) to end, by step
+	End   *int   `json:"end,omitempty" form:"end"`
+	Step  *int   `json:"step,omitempty" form:"step"`          // 1 by default, -1 when end is below start
+	Str1  string `json:"str1" form:"str1" binding:"required"` // Templates may insert the number they replace: "fizz#{n}", "{n:hex}"
+	Str2  string `json:"str2" form:"str2" binding:"required"`
+
+	NumberFormat string `json:"number_format,omitempty" form:"number_format"` // decimal by default, hex, binary, roman, words, or a zero-padded width like 05
+	Locale       string `json:"-" form:"-"`                                   // Language of number words, negotiated from the Accept-Language header
+}
+
+// IsRange reports whether the request sets a range rather than a limit
+func (r FizzBuzzRequest) IsRange() bool {
+	return r.Start != nil || r.End != nil || r.Step != nil
+}
+
+// Range returns the first and last values and the step of the sequence, a limit standing for 1 to limit by 1.
+// A range without end ends at its start.
+func (r FizzBuzzRequest) Range() (start, end, step int) {
+	if !r.IsRange() {
+		return 1, r.Limit, 1
+	}
+	start = 1
+	if r.Start != nil {
+		start = *r.Start
+	}
+	end = start
+	if r.End != nil {
+		end = *r.End
+	}
+	switch {
+	case r.Step != nil:
+		step = *r.Step
+	case end < start:
+		step = -1
+	default:
+		step = 1
+	}
+	return start, end, step
+}
+
+// StringViolation is a character str1 or str2 may not hold, listed by invalid_parameter errors
+type StringViolation struct {
+	Field     string `json:"field"`     // "str1" or "str2"
+	Offset    int    `json:"offset"`    // In bytes
+	Character string `json:"character"` // Code point, as U+202E
+	Reason    string `json:"reason"`    // "control", "bidi" or "zero_width"
 }
 
 type FizzBuzzLimits struct {
-	MaxLimit        int
-	MaxStringLength int
+	MaxLimit          int
+	MaxStringLength   int
+	MaxTemplateLength int    // Max length of Str1 and Str2 when they hold placeholders
+	MaxExpandedLength int    // Max length of a value generated from templates or a number format
+	StringLengthUnit  string // How lengths are counted: bytes, runes or graphemes
 }
 
 type FizzBuzzResponse struct {
@@ -23,7 +77,287 @@ type FizzBuzzResponse struct {
 	Duration int64    `json:"duration_ms"`
 }
 
+// Values returns the sequence, written one value per row by text encoders
+func (r FizzBuzzResponse) Values() []string {
+	return r.Result
+}
+
+// Characters of FizzBuzzCompactResponse.Pattern
+const (
+	CompactNumber = '.'
+	CompactStr1   = '1'
+	CompactStr2   = '2'
+	CompactBoth   = '3'
+)
+
+// FizzBuzzCompactResponse describes a sequence by the pattern repeating every lcm(int1, int2) positions,
+// instead of its values. Expand or At decode it.
+type FizzBuzzCompactResponse struct {
+	Limit    int    `json:"limit"`   // Number of values of the sequence
+	Start    int    `json:"start"`   // Value at position 1, 1 unless a range was requested
+	Step     int    `json:"step"`    // Difference between consecutive values, 1 unless a range was requested
+	Period   int    `json:"period"`  // Length of the pattern: lcm(int1, int2) / gcd with step, or limit when the sequence does not repeat within it
+	Pattern  string `json:"pattern"` // One character per position of the period: "." number, "1" str1, "2" str2, "3" str1str2
+	Str1     string `json:"str1"`
+	Str2     string `json:"str2"`
+	Duration int64  `json:"duration_ms"`
+}
+
+// At returns the value at position i of the sequence, from 1 to Limit. Positions outside the sequence,
+// such as any position of an empty one, return "".
+func (r FizzBuzzCompactResponse) At(i int) string {
+	if i < 1 || i > r.Limit || r.Period <= 0 {
+		return ""
+	}
+	switch r.Pattern[(i-1)%r.Period] {
+	case CompactStr1:
+		return r.Str1
+	case CompactStr2:
+		return r.Str2
+	case CompactBoth:
+		return r.Str1 + r.Str2
+	default:
+		return strconv.Itoa(r.Start + (i-1)*r.Step)
+	}
+}
+
+// Expand returns the values of the sequence, decoded as they are consumed
+func (r FizzBuzzCompactResponse) Expand() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for i := 1; i <= r.Limit; i++ {
+			if !yield(r.At(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Tokens of explained values, telling which rule produced them
+const (
+	TokenNumber   = "number"
+	TokenStr1     = "str1"
+	TokenStr2     = "str2"
+	TokenStr1Str2 = "str1str2"
+)
+
+// Rules a number may match
+const (
+	RuleInt1 = "int1"
+	RuleInt2 = "int2"
+)
+
+// FizzBuzzExplainedValue is a value of the sequence along with the rules that produced it
+type FizzBuzzExplainedValue struct {
+	Index  int      `json:"index"`  // Position in the sequence, from 1
+	Number int      `json:"number"` // Number at that position, before replacement
+	Rules  []string `json:"rules"`  // Divisors of the number: "int1" and "int2", empty for numbers left as is
+	Token  string   `json:"token"`  // "number", "str1", "str2" or "str1str2"
+	Value  string   `json:"value"`
+}
+
+// FizzBuzzExplainSummary describes the whole sequence of an explained request
+type FizzBuzzExplainSummary struct {
+	Period           int            `json:"period"`            // Positions after which the matched rules repeat, like FizzBuzzCompactResponse.Period
+	Counts           map[string]int `json:"counts"`            // Number of values of each token, zero included
+	FirstOccurrences map[string]int `json:"first_occurrences"` // Index of the first value of each token found in the sequence
+}
+
+// Record counts a value of the sequence in the summary
+func (s *FizzBuzzExplainSummary) Record(v FizzBuzzExplainedValue) {
+	s.Counts[v.Token]++
+	if _, ok := s.FirstOccurrences[v.Token]; !ok {
+		s.FirstOccurrences[v.Token] = v.Index
+	}
+}
+
+// FizzBuzzExplainResponse is the sequence of POST /fizzbuzz/generate?explain=true
+type FizzBuzzExplainResponse struct {
+	Result   []FizzBuzzExplainedValue `json:"result"`
+	Summary  FizzBuzzExplainSummary   `json:"summary"`
+	Duration int64                    `json:"duration_ms"`
+}
+
+// FizzBuzzVerifyResponse grades a submitted sequence against the sequence of a request
+type FizzBuzzVerifyResponse struct {
+	Pass          bool              `json:"pass"`
+	Expected      int               `json:"expected"`                 // Number of values of the sequence
+	Submitted     int               `json:"submitted"`                // Number of values submitted
+	Mismatches    int               `json:"mismatches"`               // Positions whose value differs, is missing or is extra
+	FirstMismatch *FizzBuzzMismatch `json:"first_mismatch,omitempty"` // Nil when the submission passes
+	Diff          FizzBuzzDiff      `json:"diff"`
+	Duration      int64             `json:"duration_ms"`
+}
+
+// FizzBuzzMismatch is a position where the submitted sequence differs from the expected one
+type FizzBuzzMismatch struct {
+	Index    int     `json:"index"`              // Position in the sequence, from 1
+	Expected *string `json:"expected,omitempty"` // Nil past the end of the sequence
+	Actual   *string `json:"actual,omitempty"`   // Nil past the end of the submission
+}
+
+// FizzBuzzDiff sums up the mismatches of a submitted sequence
+type FizzBuzzDiff struct {
+	Changed int                `json:"changed"`  // Values differing from the expected ones
+	Missing int                `json:"missing"`  // Expected values past the end of the submission
+	Extra   int                `json:"extra"`    // Submitted values past the end of the sequence
+	ByToken map[string]int     `json:"by_token"` // Changed and missing values by the token expected at their position
+	Hunks   []FizzBuzzDiffHunk `json:"hunks"`    // The first runs of consecutive mismatching positions
+}
+
+// FizzBuzzDiffHunk is a run of consecutive mismatching positions
+type FizzBuzzDiffHunk struct {
+	Start int `json:"start"`
+	End   int `json:"end"` // Included
+}
+
+// FizzBuzzInferResponse lists the requests and rules producing an observed sequence
+type FizzBuzzInferResponse struct {
+	Limit      int                      `json:"limit"`               // Length of the sequence
+	Possible   bool                     `json:"possible"`            // Whether a request or a rule set produces the sequence
+	Ambiguous  bool                     `json:"ambiguous"`           // Whether several requests produce it, or some of their divisors and strings cannot be told
+	Candidates []FizzBuzzInferCandidate `json:"candidates"`          // Every request producing the sequence
+	Truncated  bool                     `json:"truncated,omitempty"` // Whether candidates were left out past the first ones
+	Rules      []FizzBuzzRule           `json:"rules,omitempty"`     // Rule set producing the sequence when no request can
+	Conflict   *FizzBuzzInferConflict   `json:"conflict,omitempty"`  // First position no rule set explains, when impossible
+	Duration   int64                    `json:"duration_ms"`
+}
+
+// FizzBuzzInferCandidate is a request producing an observed sequence
+type FizzBuzzInferCandidate struct {
+	Int1      int      `json:"int1"`
+	Int2      int      `json:"int2"`
+	Str1      string   `json:"str1,omitempty"` // Empty when int1 divides no position
+	Str2      string   `json:"str2,omitempty"` // Empty when int2 divides no position
+	Limit     int      `json:"limit"`
+	Unbounded []string `json:"unbounded,omitempty"` // Divisors dividing no position, standing for any integer above the limit: "int1", "int2"
+}
+
+// FizzBuzzRule replaces the multiples of a divisor, the strings of the rules dividing a number being written
+// in ascending divisor order
+type FizzBuzzRule struct {
+	Divisor int    `json:"divisor"`
+	Str     string `json:"str"`
+}
+
+// FizzBuzzInferConflict is a value of a sequence that no rule set can produce along with the values before it
+type FizzBuzzInferConflict struct {
+	Index    int    `json:"index"` // Position in the sequence, from 1
+	Value    string `json:"value"`
+	Expected string `json:"expected"` // Strings of the rules found so far dividing the position, the number when none does
+	Reason   string `json:"reason"`   // "unexpected_number" or "unexpected_value"
+}
+
+// FizzBuzzAtRequest asks for the value at position N of a sequence, which may go beyond the max limit and int64
+type FizzBuzzAtRequest struct {
+	N    BigInt `form:"n" binding:"required"`
+	Int1 int64  `form:"int1" binding:"required"`
+	Int2 int64  `form:"int2" binding:"required"`
+	Str1 string `form:"str1" binding:"required"`
+	Str2 string `form:"str2" binding:"required"`
+}
+
+type FizzBuzzAtResponse struct {
+	N     BigInt `json:"n"`
+	Value string `json:"value"`
+}
+
+// FizzBuzzCountRequest asks how many positions up to Limit hold each kind of value,
+// Limit may go beyond the max limit and int64
+type FizzBuzzCountRequest struct {
+	Limit BigInt `form:"limit" binding:"required"`
+	Int1  int64  `form:"int1" binding:"required"`
+	Int2  int64  `form:"int2" binding:"required"`
+}
+
+type FizzBuzzCountResponse struct {
+	Limit    BigInt `json:"limit"`
+	Numbers  BigInt `json:"numbers"`  // Positions left as numbers
+	Str1     BigInt `json:"str1"`     // Multiples of int1 only
+	Str2     BigInt `json:"str2"`     // Multiples of int2 only
+	Str1Str2 BigInt `json:"str1str2"` // Multiples of both
+}
+
+// FizzBuzzWindowRequest asks for Size values of a sequence from position Start, which may go beyond
+// the max limit and int64. Size is bound by the max limit.
+type FizzBuzzWindowRequest struct {
+	Start BigInt `form:"start" binding:"required"`
+	Size  int    `form:"size" binding:"required"`
+	Int1  int64  `form:"int1" binding:"required"`
+	Int2  int64  `form:"int2" binding:"required"`
+	Str1  string `form:"str1" binding:"required"`
+	Str2  string `form:"str2" binding:"required"`
+}
+
+type FizzBuzzWindowResponse struct {
+	Start  BigInt   `json:"start"`
+	Result []string `json:"result"`
+}
+
+// Values returns the values of the window, written one value per row by text encoders
+func (r FizzBuzzWindowResponse) Values() []string {
+	return r.Result
+}
+
 type FizzBuzzStats struct {
-	MostFrequentRequests []FizzBuzzRequest `json:"most_frequent_request"`
-	Count                int               `json:"count"`
+	MostFrequentRequests []FizzBuzzRequest   `json:"most_frequent_request"`
+	Count                int                 `json:"count"`
+	Variants             [][]FizzBuzzRequest `json:"variants,omitempty"` // Observed requests per most frequent request, unless keyed by exact request
+}
+
+// FizzBuzzStatsFilter selects stats entries by request parameters. Nil fields match any value.
+type FizzBuzzStatsFilter struct {
+	Int1  *int    `form:"int1" json:"int1,omitempty"`
+	Int2  *int    `form:"int2" json:"int2,omitempty"`
+	Limit *int    `form:"limit" json:"limit,omitempty"`
+	Str1  *string `form:"str1" json:"str1,omitempty"`
+	Str2  *string `form:"str2" json:"str2,omitempty"`
+}
+
+func (f FizzBuzzStatsFilter) Empty() bool {
+	return f == FizzBuzzStatsFilter{}
+}
+
+func (f FizzBuzzStatsFilter) Matches(req FizzBuzzRequest) bool {
+	return (f.Int1 == nil || *f.Int1 == req.Int1) &&
+		(f.Int2 == nil || *f.Int2 == req.Int2) &&
+		(f.Limit == nil || *f.Limit == req.Limit) &&
+		(f.Str1 == nil || *f.Str1 == req.Str1) &&
+		(f.Str2 == nil || *f.Str2 == req.Str2)
+}
+
+type FizzBuzzStatsSummary struct {
+	TotalRequests   int                   `json:"total_requests"`  // Successful requests recorded
+	UniqueRequests  int                   `json:"unique_requests"` // Distinct successful requests recorded
+	ErrorRequests   map[string]int        `json:"error_requests"`  // Failed requests by error code
+	LimitHistogram  []FizzBuzzLimitBucket `json:"limit_histogram"` // Request counts by limit order of magnitude
+	TopDivisorPairs []FizzBuzzDivisorPair `json:"top_divisor_pairs"`
+	TopStringPairs  []FizzBuzzStringPair  `json:"top_string_pairs"`
+}
+
+type FizzBuzzLimitBucket struct {
+	Min   int `json:"min"`
+	Max   int `json:"max"`
+	Count int `json:"count"`
+}
+
+type FizzBuzzDivisorPair struct {
+	Int1  int `json:"int1"`
+	Int2  int `json:"int2"`
+	Count int `json:"count"`
+}
+
+type FizzBuzzStringPair struct {
+	Str1  string `json:"str1"`
+	Str2  string `json:"str2"`
+	Count int    `json:"count"`
+}
+
+// ConfigReload describes an attempt to reload the config
+type ConfigReload struct {
+	Time    time.Time `json:"time"`
+	Trigger string    `json:"trigger"`           // What started the reload: "signal" or "file"
+	Status  string    `json:"status"`            // "applied", "unchanged" or "rejected"
+	Error   string    `json:"error,omitempty"`   // Why the new config was rejected
+	Applied []string  `json:"applied,omitempty"` // Settings changed by the reload
+	Ignored []string  `json:"ignored,omitempty"` // Settings changed in the config that require a restart
 }
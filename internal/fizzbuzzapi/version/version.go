@@ -0,0 +1,48 @@
+package version
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+)
+
+// Build information, injected at build time with:
+//
+//	go build -ldflags "-X fizzbuzz-api/internal/fizzbuzzapi/version.Version=v1.2.3 -X fizzbuzz-api/internal/fizzbuzzapi/version.Commit=abc123 -X fizzbuzz-api/internal/fizzbuzzapi/version.Date=2025-01-01T00:00:00Z"
+//
+// Commit and Date fall back to the VCS information recorded by the Go toolchain when not injected.
+var (
+	Version = "dev"
+	Commit  = ""
+	Date    = ""
+)
+
+func init() {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return
+	}
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			if Commit == "" {
+				Commit = setting.Value
+			}
+		case "vcs.time":
+			if Date == "" {
+				Date = setting.Value
+			}
+		}
+	}
+}
+
+func String() string {
+	commit, date := Commit, Date
+	if commit == "" {
+		commit = "unknown"
+	}
+	if date == "" {
+		date = "unknown"
+	}
+	return fmt.Sprintf("%s (commit %s, built %s, %s %s/%s)", Version, commit, date, runtime.Version(), runtime.GOOS, runtime.GOARCH)
+}
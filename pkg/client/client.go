@@ -0,0 +1,360 @@
+// Package client is a Go client of the fizzbuzz-api REST API.
+//
+//	c := client.New("http://localhost:4255")
+//	resp, err := c.Generate(ctx, client.FizzBuzzRequest{Int1: 3, Int2: 5, Limit: 15, Str1: "fizz", Str2: "buzz"})
+//	if errors.Is(err, client.ErrLimitExceeded) {
+//		...
+//	}
+//
+// Requests answered 429 or 5xx (except 501) and failed connections are retried with exponential backoff,
+// waiting as long as the Retry-After header of the response asks when it is set.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Header carrying the API key of admin routes
+const APIKeyHeader = "X-API-Key"
+
+const (
+	defaultMaxRetries = 3
+	defaultMinBackoff = 100 * time.Millisecond
+	defaultMaxBackoff = 5 * time.Second
+)
+
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	apiKey     string
+	language   string
+
+	maxRetries int
+	minBackoff time.Duration
+	maxBackoff time.Duration
+}
+
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client sending the requests, http.DefaultClient by default
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) { c.httpClient = httpClient }
+}
+
+// WithAPIKey sets the API key sent to admin routes
+func WithAPIKey(key string) Option {
+	return func(c *Client) { c.apiKey = key }
+}
+
+// WithLanguage sets the Accept-Language header, the language of number words and error messages: en, fr or es
+func WithLanguage(language string) Option {
+	return func(c *Client) { c.language = language }
+}
+
+// WithRetries sets how many times a failed request is retried, 3 by default. 0 disables retries.
+func WithRetries(maxRetries int) Option {
+	return func(c *Client) { c.maxRetries = maxRetries }
+}
+
+// WithBackoff sets the wait before the first retry, doubled on each retry up to max. Defaults to 100ms and 5s.
+func WithBackoff(min, max time.Duration) Option {
+	return func(c *Client) { c.minBackoff, c.maxBackoff = min, max }
+}
+
+// New returns a client of the API served at baseURL, e.g. "https://fizzbuzz.example.com"
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: http.DefaultClient,
+		maxRetries: defaultMaxRetries,
+		minBackoff: defaultMinBackoff,
+		maxBackoff: defaultMaxBackoff,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Generate returns the whole sequence of the request
+func (c *Client) Generate(ctx context.Context, req FizzBuzzRequest) (FizzBuzzResponse, error) {
+	var resp FizzBuzzResponse
+	err := c.call(ctx, http.MethodPost, "/fizzbuzz/generate", nil, req, &resp)
+	return resp, err
+}
+
+// GenerateCompact returns the pattern of the sequence of the request, small whatever the limit
+func (c *Client) GenerateCompact(ctx context.Context, req FizzBuzzRequest) (FizzBuzzCompactResponse, error) {
+	var resp FizzBuzzCompactResponse
+	err := c.call(ctx, http.MethodPost, "/fizzbuzz/generate", url.Values{"format": {"compact"}}, req, &resp)
+	return resp, err
+}
+
+// Explain returns the values of the sequence of the request along with the rules that produced them,
+// and a summary of the sequence
+func (c *Client) Explain(ctx context.Context, req FizzBuzzRequest) (FizzBuzzExplainResponse, error) {
+	var resp FizzBuzzExplainResponse
+	err := c.call(ctx, http.MethodPost, "/fizzbuzz/generate", url.Values{"explain": {"true"}}, req, &resp)
+	return resp, err
+}
+
+// Verify grades a submitted sequence against the sequence of the request: whether it passes, its first mismatch
+// and a summary of its differences
+func (c *Client) Verify(ctx context.Context, req FizzBuzzRequest, submitted []string) (FizzBuzzVerifyResponse, error) {
+	query := url.Values{
+		"int1": {strconv.Itoa(req.Int1)},
+		"int2": {strconv.Itoa(req.Int2)},
+		"str1": {req.Str1},
+		"str2": {req.Str2},
+	}
+	if req.Limit != 0 {
+		query.Set("limit", strconv.Itoa(req.Limit))
+	}
+	for name, value := range map[string]*int{"start": req.Start, "end": req.End, "step": req.Step} {
+		if value != nil {
+			query.Set(name, strconv.Itoa(*value))
+		}
+	}
+	if req.NumberFormat != "" {
+		query.Set("number_format", req.NumberFormat)
+	}
+	if submitted == nil {
+		submitted = []string{} // An empty submission rather than null
+	}
+
+	var resp FizzBuzzVerifyResponse
+	err := c.call(ctx, http.MethodPost, "/fizzbuzz/verify", query, submitted, &resp)
+	return resp, err
+}
+
+// Infer returns the requests producing an observed sequence, or the rule set producing it when no request can
+func (c *Client) Infer(ctx context.Context, sequence []string) (FizzBuzzInferResponse, error) {
+	var resp FizzBuzzInferResponse
+	err := c.call(ctx, http.MethodPost, "/fizzbuzz/infer", nil, sequence, &resp)
+	return resp, err
+}
+
+// ValueAt returns the value at position req.N of a sequence, without generating it
+func (c *Client) ValueAt(ctx context.Context, req FizzBuzzAtRequest) (FizzBuzzAtResponse, error) {
+	query := url.Values{
+		"n":    {req.N.String()},
+		"int1": {strconv.FormatInt(req.Int1, 10)},
+		"int2": {strconv.FormatInt(req.Int2, 10)},
+		"str1": {req.Str1},
+		"str2": {req.Str2},
+	}
+	var resp FizzBuzzAtResponse
+	err := c.call(ctx, http.MethodGet, "/fizzbuzz/at", query, nil, &resp)
+	return resp, err
+}
+
+// Count returns how many positions up to req.Limit hold each kind of value, without generating them
+func (c *Client) Count(ctx context.Context, req FizzBuzzCountRequest) (FizzBuzzCountResponse, error) {
+	query := url.Values{
+		"limit": {req.Limit.String()},
+		"int1":  {strconv.FormatInt(req.Int1, 10)},
+		"int2":  {strconv.FormatInt(req.Int2, 10)},
+	}
+	var resp FizzBuzzCountResponse
+	err := c.call(ctx, http.MethodGet, "/fizzbuzz/count", query, nil, &resp)
+	return resp, err
+}
+
+// Window returns req.Size values of a sequence from position req.Start, without generating the ones before
+func (c *Client) Window(ctx context.Context, req FizzBuzzWindowRequest) (FizzBuzzWindowResponse, error) {
+	query := url.Values{
+		"start": {req.Start.String()},
+		"size":  {strconv.Itoa(req.Size)},
+		"int1":  {strconv.FormatInt(req.Int1, 10)},
+		"int2":  {strconv.FormatInt(req.Int2, 10)},
+		"str1":  {req.Str1},
+		"str2":  {req.Str2},
+	}
+	var resp FizzBuzzWindowResponse
+	err := c.call(ctx, http.MethodGet, "/fizzbuzz/window", query, nil, &resp)
+	return resp, err
+}
+
+// Stats returns the most frequent requests
+func (c *Client) Stats(ctx context.Context) (FizzBuzzStats, error) {
+	var resp struct {
+		Stats FizzBuzzStats `json:"stats"`
+	}
+	err := c.call(ctx, http.MethodGet, "/fizzbuzz/stats", nil, nil, &resp)
+	return resp.Stats, err
+}
+
+// StatsSummary returns aggregated metrics over the recorded requests
+func (c *Client) StatsSummary(ctx context.Context) (FizzBuzzStatsSummary, error) {
+	var resp struct {
+		Summary FizzBuzzStatsSummary `json:"summary"`
+	}
+	err := c.call(ctx, http.MethodGet, "/fizzbuzz/stats/summary", nil, nil, &resp)
+	return resp.Summary, err
+}
+
+// ResetStats removes the stats entries matching filter, all of them with an empty filter. It requires an API key.
+func (c *Client) ResetStats(ctx context.Context, filter FizzBuzzStatsFilter) (int, error) {
+	query := url.Values{}
+	for name, value := range map[string]*int{"int1": filter.Int1, "int2": filter.Int2, "limit": filter.Limit} {
+		if value != nil {
+			query.Set(name, strconv.Itoa(*value))
+		}
+	}
+	for name, value := range map[string]*string{"str1": filter.Str1, "str2": filter.Str2} {
+		if value != nil {
+			query.Set(name, *value)
+		}
+	}
+
+	var resp struct {
+		Removed int `json:"removed"`
+	}
+	err := c.call(ctx, http.MethodDelete, "/fizzbuzz/stats", query, nil, &resp)
+	return resp.Removed, err
+}
+
+// call sends a request and decodes its JSON response into out
+func (c *Client) call(ctx context.Context, method, path string, query url.Values, body, out any) error {
+	resp, err := c.do(ctx, method, path, query, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("fizzbuzz-api: invalid response: %w", err)
+	}
+	return nil
+}
+
+// do sends a request, retrying on transient failures, and returns the successful response.
+// Error statuses are returned as *APIError.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body any) (*http.Response, error) {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return nil, err
+		}
+	}
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, method, target, bytes.NewReader(payload))
+		if err != nil {
+			return nil, err
+		}
+		if payload != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		req.Header.Set("Accept", "application/json")
+		if c.apiKey != "" {
+			req.Header.Set(APIKeyHeader, c.apiKey)
+		}
+		if c.language != "" {
+			req.Header.Set("Accept-Language", c.language)
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			if ctx.Err() != nil || attempt >= c.maxRetries {
+				return nil, err
+			}
+			if err := c.wait(ctx, c.backoff(attempt)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if resp.StatusCode < 300 {
+			return resp, nil
+		}
+
+		apiErr := readAPIError(resp)
+		if !retryable(resp.StatusCode) || attempt >= c.maxRetries {
+			return nil, apiErr
+		}
+		delay, ok := retryAfter(resp.Header.Get("Retry-After"))
+		if !ok {
+			delay = c.backoff(attempt)
+		}
+		if err := c.wait(ctx, delay); err != nil {
+			return nil, errors.Join(apiErr, err)
+		}
+	}
+}
+
+func retryable(status int) bool {
+	return status == http.StatusTooManyRequests || (status >= 500 && status != http.StatusNotImplemented)
+}
+
+// backoff returns the wait before retry attempt+1: exponential with jitter, bounded by maxBackoff
+func (c *Client) backoff(attempt int) time.Duration {
+	d := c.maxBackoff
+	if attempt < 32 && c.minBackoff<<attempt < c.maxBackoff {
+		d = c.minBackoff << attempt
+	}
+	if d <= 0 {
+		return 0
+	}
+	return d/2 + rand.N(d/2+1)
+}
+
+// retryAfter parses a Retry-After header, in seconds or as an HTTP date
+func retryAfter(header string) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(header); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
+
+func (c *Client) wait(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// readAPIError reads an {"code": "...", "error": "..."} response, falling back to the raw body
+func readAPIError(resp *http.Response) *APIError {
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+
+	apiErr := &APIError{StatusCode: resp.StatusCode}
+	var payload struct {
+		Code       string            `json:"code"`
+		Error      string            `json:"error"`
+		Violations []StringViolation `json:"violations"`
+	}
+	if json.Unmarshal(body, &payload) == nil && payload.Error != "" {
+		apiErr.Code, apiErr.Message, apiErr.Violations = payload.Code, payload.Error, payload.Violations
+	} else {
+		apiErr.Message = strings.TrimSpace(string(body))
+	}
+	return apiErr
+}
@@ -0,0 +1,506 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fizzbuzz-api/internal/fizzbuzzapi/config"
+	fbhttp "fizzbuzz-api/internal/fizzbuzzapi/http"
+	"fizzbuzz-api/internal/fizzbuzzapi/logger"
+	"fizzbuzz-api/internal/fizzbuzzapi/types"
+	"io"
+	"math"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAPIKey = "secret"
+
+// newTestServer serves the real router of the API, through wrap when set
+func newTestServer(t *testing.T, wrap func(http.Handler) http.Handler) *httptest.Server {
+	cfg := &config.Config{
+		MaxFizzBuzzLimit:  100,
+		MaxStringLength:   10,
+		StatsStorage:      "inmemory",
+		MaxTemplateLength: 20,
+		MaxExpandedLength: 40,
+		StringLengthUnit:  "graphemes",
+		StatsKeying:       "exact",
+		AdminAPIKey:       testAPIKey,
+		MaxSubmissionSize: 1 << 20,
+	}
+	s, err := fbhttp.NewServer(cfg, logger.NewNopLogger())
+	require.NoError(t, err)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	s.Routes(router)
+
+	var handler http.Handler = router
+	if wrap != nil {
+		handler = wrap(router)
+	}
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// failing answers status to the first n requests, with a Retry-After header when set
+func failing(n int32, status int, retryAfter string, attempts *atomic.Int32) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if attempts.Add(1) <= n {
+				if retryAfter != "" {
+					w.Header().Set("Retry-After", retryAfter)
+				}
+				w.WriteHeader(status)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+var fizzBuzz = FizzBuzzRequest{Int1: 3, Int2: 5, Limit: 15, Str1: "fizz", Str2: "buzz"}
+
+func Test_Generate(t *testing.T) {
+	assert := assert.New(t)
+	c := New(newTestServer(t, nil).URL)
+
+	resp, err := c.Generate(context.Background(), fizzBuzz)
+	assert.NoError(err)
+	assert.Len(resp.Result, 15)
+	assert.Equal("fizzbuzz", resp.Result[14])
+}
+
+func Test_GenerateCompact(t *testing.T) {
+	c := New(newTestServer(t, nil).URL)
+
+	compact, err := c.GenerateCompact(context.Background(), fizzBuzz)
+	assert.NoError(t, err)
+	assert.Equal(t, 15, compact.Period)
+
+	resp, _ := c.Generate(context.Background(), fizzBuzz)
+	assert.Equal(t, resp.Result, slices.Collect(compact.Expand()))
+	assert.Equal(t, "", compact.At(16))
+
+	// An empty sequence has no period
+	var empty FizzBuzzCompactResponse
+	assert.Equal(t, "", empty.At(1))
+	assert.Empty(t, slices.Collect(empty.Expand()))
+}
+
+func Test_Explain(t *testing.T) {
+	assert := assert.New(t)
+	c := New(newTestServer(t, nil).URL)
+
+	explained, err := c.Explain(context.Background(), fizzBuzz)
+	assert.NoError(err)
+	resp, _ := c.Generate(context.Background(), fizzBuzz)
+	assert.Len(explained.Result, len(resp.Result))
+	for k, v := range explained.Result {
+		assert.Equal(resp.Result[k], v.Value)
+	}
+	assert.Equal(FizzBuzzExplainedValue{Index: 15, Number: 15, Rules: []string{"int1", "int2"}, Token: "str1str2", Value: resp.Result[14]}, explained.Result[14])
+	assert.Equal(15, explained.Summary.Period)
+	assert.Equal(3, explained.Summary.FirstOccurrences["str1"])
+}
+
+func Test_Verify(t *testing.T) {
+	assert := assert.New(t)
+	c := New(newTestServer(t, nil).URL)
+
+	resp, _ := c.Generate(context.Background(), fizzBuzz)
+	verified, err := c.Verify(context.Background(), fizzBuzz, resp.Result)
+	assert.NoError(err)
+	assert.True(verified.Pass)
+	assert.Nil(verified.FirstMismatch)
+
+	verified, err = c.Verify(context.Background(), fizzBuzz.Range(1, 5, 1), []string{"1", "2", "fizz", "4", "fizz", "6"})
+	assert.NoError(err)
+	assert.False(verified.Pass)
+	assert.Equal(2, verified.Mismatches)
+	assert.Equal(5, verified.FirstMismatch.Index)
+	assert.Equal("buzz", *verified.FirstMismatch.Expected)
+	assert.Equal(FizzBuzzDiff{Changed: 1, Extra: 1, ByToken: map[string]int{"str2": 1}, Hunks: []FizzBuzzDiffHunk{{Start: 5, End: 6}}}, verified.Diff)
+
+	verified, err = c.Verify(context.Background(), fizzBuzz, nil)
+	assert.NoError(err)
+	assert.Equal(15, verified.Diff.Missing)
+}
+
+func Test_Infer(t *testing.T) {
+	assert := assert.New(t)
+	c := New(newTestServer(t, nil).URL)
+
+	resp, _ := c.Generate(context.Background(), fizzBuzz)
+	inferred, err := c.Infer(context.Background(), resp.Result)
+	assert.NoError(err)
+	assert.True(inferred.Possible)
+	assert.False(inferred.Ambiguous)
+	assert.Equal([]FizzBuzzInferCandidate{{Int1: 3, Int2: 5, Str1: "fizz", Str2: "buzz", Limit: 15}}, inferred.Candidates)
+
+	inferred, err = c.Infer(context.Background(), []string{"1", "2", "fizz", "4", "5", "fizz", "7", "8", "9"})
+	assert.NoError(err)
+	assert.False(inferred.Possible)
+	assert.Equal(&FizzBuzzInferConflict{Index: 9, Value: "9", Expected: "fizz", Reason: "unexpected_number"}, inferred.Conflict)
+
+	_, err = c.Infer(context.Background(), []string{})
+	assert.True(errors.Is(err, ErrInvalidParameter))
+}
+
+func Test_Generate_Range(t *testing.T) {
+	assert := assert.New(t)
+	c := New(newTestServer(t, nil).URL)
+	req := fizzBuzz.Range(5, -5, -2)
+
+	resp, err := c.Generate(context.Background(), req)
+	assert.NoError(err)
+	assert.Equal([]string{"buzz", "fizz", "1", "-1", "fizz", "buzz"}, resp.Result)
+
+	compact, err := c.GenerateCompact(context.Background(), req)
+	assert.NoError(err)
+	assert.Equal(resp.Result, slices.Collect(compact.Expand()))
+}
+
+func Test_Language(t *testing.T) {
+	assert := assert.New(t)
+	c := New(newTestServer(t, nil).URL, WithLanguage("fr-CA, en;q=0.5"))
+	req := FizzBuzzRequest{Int1: 3, Int2: 5, Limit: 4, Str1: "fizz", Str2: "buzz", NumberFormat: "words"}
+
+	resp, err := c.Generate(context.Background(), req)
+	assert.NoError(err)
+	assert.Equal([]string{"un", "deux", "fizz", "quatre"}, resp.Result)
+
+	req.NumberFormat = "roman"
+	_, err = c.Generate(context.Background(), req.Range(0, 4, 1))
+	var apiErr *APIError
+	if assert.ErrorAs(err, &apiErr) {
+		assert.Equal(CodeInvalidParameter, apiErr.Code)
+		assert.Contains(apiErr.Message, "format de nombre invalide")
+	}
+}
+
+func Test_Generate_UnsafeCharacters(t *testing.T) {
+	assert := assert.New(t)
+	c := New(newTestServer(t, nil).URL)
+
+	_, err := c.Generate(context.Background(), FizzBuzzRequest{Int1: 3, Int2: 5, Limit: 3, Str1: "fizz\u202e", Str2: "buzz"})
+	assert.ErrorIs(err, ErrInvalidParameter)
+	var apiErr *APIError
+	if assert.ErrorAs(err, &apiErr) {
+		assert.Equal([]StringViolation{{Field: "str1", Offset: 4, Character: "U+202E", Reason: "bidi"}}, apiErr.Violations)
+	}
+}
+
+func Test_ValueAtAndCount(t *testing.T) {
+	assert := assert.New(t)
+	c := New(newTestServer(t, nil).URL)
+	ctx := context.Background()
+
+	at, err := c.ValueAt(ctx, FizzBuzzAtRequest{N: big.NewInt(987654330), Int1: 3, Int2: 5, Str1: "fizz", Str2: "buzz"})
+	assert.NoError(err)
+	assert.Equal("fizzbuzz", at.Value)
+
+	huge, _ := new(big.Int).SetString("1000000000000000000000000000002", 10)
+	at, err = c.ValueAt(ctx, FizzBuzzAtRequest{N: huge, Int1: 3, Int2: 5, Str1: "fizz", Str2: "buzz"})
+	assert.NoError(err)
+	assert.Equal("fizz", at.Value)
+	assert.Equal(huge.String(), at.N.String())
+
+	count, err := c.Count(ctx, FizzBuzzCountRequest{Limit: big.NewInt(15), Int1: 3, Int2: 5})
+	assert.NoError(err)
+	for got, want := range map[*BigInt]int64{&count.Limit: 15, &count.Numbers: 8, &count.Str1: 4, &count.Str2: 2, &count.Str1Str2: 1} {
+		assert.Equal(want, got.Int64())
+	}
+
+	_, err = c.Count(ctx, FizzBuzzCountRequest{Limit: big.NewInt(15), Int1: 0, Int2: 5})
+	assert.ErrorIs(err, ErrInvalidRequest)
+}
+
+func Test_Window(t *testing.T) {
+	assert := assert.New(t)
+	c := New(newTestServer(t, nil).URL)
+
+	start := new(big.Int).SetInt64(math.MaxInt64 - 2)
+	window, err := c.Window(context.Background(), FizzBuzzWindowRequest{Start: start, Size: 6, Int1: 3, Int2: 5, Str1: "fizz", Str2: "buzz"})
+	assert.NoError(err)
+	assert.Equal(start.String(), window.Start.String())
+	assert.Equal([]string{"buzz", "fizz", "9223372036854775807", "9223372036854775808", "fizz", "buzz"}, window.Result)
+}
+
+func Test_Generate_Errors(t *testing.T) {
+	c := New(newTestServer(t, nil).URL)
+
+	tests := []struct {
+		name   string
+		req    FizzBuzzRequest
+		status int
+		err    error
+	}{
+		{"missing parameter", FizzBuzzRequest{Int1: 3, Limit: 15, Str1: "fizz", Str2: "buzz"}, 400, ErrInvalidRequest},
+		{"limit exceeded", FizzBuzzRequest{Int1: 3, Int2: 5, Limit: 1000, Str1: "fizz", Str2: "buzz"}, 422, ErrLimitExceeded},
+		{"string length exceeded", FizzBuzzRequest{Int1: 3, Int2: 5, Limit: 15, Str1: "fizzfizzfizz", Str2: "buzz"}, 422, ErrStringLengthExceeded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := c.Generate(context.Background(), tt.req)
+			assert.ErrorIs(t, err, tt.err)
+
+			var apiErr *APIError
+			if assert.ErrorAs(t, err, &apiErr) {
+				assert.Equal(t, tt.status, apiErr.StatusCode)
+			}
+		})
+	}
+}
+
+func Test_GenerateStream(t *testing.T) {
+	assert := assert.New(t)
+	c := New(newTestServer(t, nil).URL)
+
+	var values []string
+	for value, err := range c.GenerateStream(context.Background(), fizzBuzz) {
+		require.NoError(t, err)
+		values = append(values, value)
+	}
+	resp, _ := c.Generate(context.Background(), fizzBuzz)
+	assert.Equal(resp.Result, values)
+
+	values = nil
+	for value, err := range c.GenerateStream(context.Background(), fizzBuzz) {
+		require.NoError(t, err)
+		if values = append(values, value); len(values) == 3 {
+			break
+		}
+	}
+	assert.Equal([]string{"1", "2", "fizz"}, values)
+}
+
+// truncating cuts streamed responses after their first n lines, like a server failing midway
+func truncating(n int) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rec := httptest.NewRecorder()
+			next.ServeHTTP(rec, r)
+			lines := strings.SplitAfter(rec.Body.String(), "\n")
+			w.WriteHeader(rec.Code)
+			io.WriteString(w, strings.Join(lines[:min(n, len(lines))], ""))
+		})
+	}
+}
+
+func Test_GenerateStream_Truncated(t *testing.T) {
+	c := New(newTestServer(t, truncating(3)).URL)
+	defaultStep := fizzBuzz.Range(-5, 5, 1)
+	defaultStep.Step = nil
+	tests := []struct {
+		name string
+		req  FizzBuzzRequest
+	}{
+		{"limit", fizzBuzz},
+		{"range", fizzBuzz.Range(10, 1, -1)},
+		{"range with default step", defaultStep},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var values []string
+			var err error
+			for value, e := range c.GenerateStream(context.Background(), tt.req) {
+				if e != nil {
+					err = e
+					break
+				}
+				values = append(values, value)
+			}
+			assert.Len(t, values, 3)
+			assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+		})
+	}
+}
+
+func Test_RequestLength(t *testing.T) {
+	ptr := func(i int) *int { return &i }
+	tests := []struct {
+		req  FizzBuzzRequest
+		want uint64
+	}{
+		{FizzBuzzRequest{Limit: 15}, 15},
+		{FizzBuzzRequest{Limit: -1}, 0},
+		{fizzBuzz.Range(-50, 50, 1), 101},
+		{fizzBuzz.Range(10, 1, -3), 4},
+		{fizzBuzz.Range(1, 10, -1), 0},
+		{FizzBuzzRequest{End: ptr(5)}, 5},
+		{FizzBuzzRequest{Start: ptr(5), End: ptr(1)}, 5},
+		{FizzBuzzRequest{Start: ptr(5)}, 1},
+		{fizzBuzz.Range(math.MinInt, math.MaxInt-1, 1), math.MaxUint64},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.req.length(), "%+v", tt.req)
+	}
+}
+
+func Test_GenerateStream_Error(t *testing.T) {
+	c := New(newTestServer(t, nil).URL)
+	req := fizzBuzz
+	req.Limit = 1000
+
+	var errs []error
+	for value, err := range c.GenerateStream(context.Background(), req) {
+		assert.Empty(t, value)
+		errs = append(errs, err)
+	}
+	require.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], ErrLimitExceeded)
+}
+
+func Test_Stats(t *testing.T) {
+	assert := assert.New(t)
+	c := New(newTestServer(t, nil).URL, WithAPIKey(testAPIKey))
+	ctx := context.Background()
+
+	for range 2 {
+		_, err := c.Generate(ctx, fizzBuzz)
+		require.NoError(t, err)
+	}
+	stats, err := c.Stats(ctx)
+	assert.NoError(err)
+	assert.Equal(2, stats.Count)
+	assert.Equal([]FizzBuzzRequest{fizzBuzz}, stats.MostFrequentRequests)
+
+	summary, err := c.StatsSummary(ctx)
+	assert.NoError(err)
+	assert.Equal(2, summary.TotalRequests)
+
+	int1 := 3
+	removed, err := c.ResetStats(ctx, FizzBuzzStatsFilter{Int1: &int1})
+	assert.NoError(err)
+	assert.Equal(1, removed)
+}
+
+func Test_ResetStats_Unauthorized(t *testing.T) {
+	c := New(newTestServer(t, nil).URL, WithAPIKey("wrong"))
+
+	_, err := c.ResetStats(context.Background(), FizzBuzzStatsFilter{})
+	assert.ErrorIs(t, err, ErrUnauthorized)
+}
+
+func Test_Retry(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		retryAfter string
+	}{
+		{"unavailable with backoff", http.StatusServiceUnavailable, ""},
+		{"throttled with Retry-After", http.StatusTooManyRequests, "0"},
+		{"Retry-After date", http.StatusServiceUnavailable, time.Now().UTC().Format(http.TimeFormat)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			server := newTestServer(t, failing(2, tt.status, tt.retryAfter, &attempts))
+			c := New(server.URL, WithBackoff(time.Millisecond, 5*time.Millisecond))
+
+			resp, err := c.Generate(context.Background(), fizzBuzz)
+			assert.NoError(t, err)
+			assert.Len(t, resp.Result, 15)
+			assert.Equal(t, int32(3), attempts.Load())
+		})
+	}
+}
+
+func Test_Retry_Exhausted(t *testing.T) {
+	assert := assert.New(t)
+	var attempts atomic.Int32
+	server := newTestServer(t, failing(10, http.StatusBadGateway, "", &attempts))
+	c := New(server.URL, WithRetries(2), WithBackoff(time.Millisecond, time.Millisecond))
+
+	_, err := c.Generate(context.Background(), fizzBuzz)
+	var apiErr *APIError
+	assert.ErrorAs(err, &apiErr)
+	assert.Equal(http.StatusBadGateway, apiErr.StatusCode)
+	assert.Equal(int32(3), attempts.Load())
+}
+
+func Test_Retry_NotRetryable(t *testing.T) {
+	var attempts atomic.Int32
+	server := newTestServer(t, failing(1, http.StatusNotImplemented, "", &attempts))
+	c := New(server.URL, WithBackoff(time.Millisecond, time.Millisecond))
+
+	_, err := c.Generate(context.Background(), fizzBuzz)
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), attempts.Load())
+}
+
+func Test_Retry_ContextCanceled(t *testing.T) {
+	var attempts atomic.Int32
+	server := newTestServer(t, failing(10, http.StatusTooManyRequests, "3600", &attempts))
+	c := New(server.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := c.Generate(ctx, fizzBuzz)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second, "Retry-After waits are interrupted by the context")
+}
+
+// The client types must keep mirroring the JSON bodies of the API
+func Test_TypesMirrorAPI(t *testing.T) {
+	mirrors := map[reflect.Type]reflect.Type{
+		reflect.TypeFor[FizzBuzzRequest]():         reflect.TypeFor[types.FizzBuzzRequest](),
+		reflect.TypeFor[FizzBuzzResponse]():        reflect.TypeFor[types.FizzBuzzResponse](),
+		reflect.TypeFor[FizzBuzzCompactResponse](): reflect.TypeFor[types.FizzBuzzCompactResponse](),
+		reflect.TypeFor[FizzBuzzExplainResponse](): reflect.TypeFor[types.FizzBuzzExplainResponse](),
+		reflect.TypeFor[FizzBuzzExplainedValue]():  reflect.TypeFor[types.FizzBuzzExplainedValue](),
+		reflect.TypeFor[FizzBuzzExplainSummary]():  reflect.TypeFor[types.FizzBuzzExplainSummary](),
+		reflect.TypeFor[FizzBuzzVerifyResponse]():  reflect.TypeFor[types.FizzBuzzVerifyResponse](),
+		reflect.TypeFor[FizzBuzzMismatch]():        reflect.TypeFor[types.FizzBuzzMismatch](),
+		reflect.TypeFor[FizzBuzzDiff]():            reflect.TypeFor[types.FizzBuzzDiff](),
+		reflect.TypeFor[FizzBuzzDiffHunk]():        reflect.TypeFor[types.FizzBuzzDiffHunk](),
+		reflect.TypeFor[FizzBuzzInferResponse]():   reflect.TypeFor[types.FizzBuzzInferResponse](),
+		reflect.TypeFor[FizzBuzzInferCandidate]():  reflect.TypeFor[types.FizzBuzzInferCandidate](),
+		reflect.TypeFor[FizzBuzzRule]():            reflect.TypeFor[types.FizzBuzzRule](),
+		reflect.TypeFor[FizzBuzzInferConflict]():   reflect.TypeFor[types.FizzBuzzInferConflict](),
+		reflect.TypeFor[FizzBuzzAtResponse]():      reflect.TypeFor[types.FizzBuzzAtResponse](),
+		reflect.TypeFor[FizzBuzzCountResponse]():   reflect.TypeFor[types.FizzBuzzCountResponse](),
+		reflect.TypeFor[FizzBuzzWindowResponse]():  reflect.TypeFor[types.FizzBuzzWindowResponse](),
+		reflect.TypeFor[FizzBuzzStats]():           reflect.TypeFor[types.FizzBuzzStats](),
+		reflect.TypeFor[FizzBuzzStatsSummary]():    reflect.TypeFor[types.FizzBuzzStatsSummary](),
+		reflect.TypeFor[FizzBuzzLimitBucket]():     reflect.TypeFor[types.FizzBuzzLimitBucket](),
+		reflect.TypeFor[FizzBuzzDivisorPair]():     reflect.TypeFor[types.FizzBuzzDivisorPair](),
+		reflect.TypeFor[FizzBuzzStringPair]():      reflect.TypeFor[types.FizzBuzzStringPair](),
+		reflect.TypeFor[StringViolation]():         reflect.TypeFor[types.StringViolation](),
+	}
+	for mirror, api := range mirrors {
+		assert.Equal(t, jsonFields(api), jsonFields(mirror), "%s does not mirror %s", mirror, api)
+	}
+}
+
+func jsonFields(t reflect.Type) map[string]reflect.Kind {
+	fields := map[string]reflect.Kind{}
+	for i := range t.NumField() {
+		if tag := t.Field(i).Tag.Get("json"); tag != "-" {
+			fields[tag] = t.Field(i).Type.Kind()
+		}
+	}
+	return fields
+}
+
+func Test_APIError(t *testing.T) {
+	err := error(&APIError{StatusCode: 422, Code: CodeLimitExceeded, Message: "limit exceeds maximum allowed"})
+	assert.True(t, errors.Is(err, ErrLimitExceeded))
+	assert.False(t, errors.Is(err, ErrInvalidRequest))
+	assert.Equal(t, "fizzbuzz-api: status 422: limit_exceeded: limit exceeds maximum allowed", err.Error())
+}
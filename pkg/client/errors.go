@@ -0,0 +1,66 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Error codes returned by the API in the "code" field of error responses
+const (
+	CodeInvalidRequest       = "invalid_request"
+	CodeInvalidParameter     = "invalid_parameter"
+	CodeLimitExceeded        = "limit_exceeded"
+	CodeStringLengthExceeded = "string_length_exceeded"
+	CodeInternal             = "internal_error"
+	CodeUnauthorized         = "unauthorized"
+	CodeAdminDisabled        = "admin_disabled"
+	CodeNotImplemented       = "not_implemented"
+	CodeNotAcceptable        = "not_acceptable"
+	CodeUnsupportedEncoding  = "unsupported_encoding"
+)
+
+// Errors matching the error codes of the API, to use with errors.Is
+var (
+	ErrInvalidRequest       = errors.New("invalid request")
+	ErrInvalidParameter     = errors.New("invalid parameter")
+	ErrLimitExceeded        = errors.New("limit exceeded")
+	ErrStringLengthExceeded = errors.New("string length exceeded")
+	ErrInternal             = errors.New("internal server error")
+	ErrUnauthorized         = errors.New("invalid API key")
+	ErrAdminDisabled        = errors.New("admin API disabled")
+	ErrNotImplemented       = errors.New("not implemented by the server")
+	ErrNotAcceptable        = errors.New("no acceptable response format")
+	ErrUnsupportedEncoding  = errors.New("unsupported request encoding")
+)
+
+var codeErrors = map[string]error{
+	CodeInvalidRequest:       ErrInvalidRequest,
+	CodeInvalidParameter:     ErrInvalidParameter,
+	CodeLimitExceeded:        ErrLimitExceeded,
+	CodeStringLengthExceeded: ErrStringLengthExceeded,
+	CodeInternal:             ErrInternal,
+	CodeUnauthorized:         ErrUnauthorized,
+	CodeAdminDisabled:        ErrAdminDisabled,
+	CodeNotImplemented:       ErrNotImplemented,
+	CodeNotAcceptable:        ErrNotAcceptable,
+	CodeUnsupportedEncoding:  ErrUnsupportedEncoding,
+}
+
+// APIError is returned for responses with an error status. It matches the Err* value of its code with errors.Is.
+type APIError struct {
+	StatusCode int    // HTTP status of the response
+	Code       string // Error code of the API, empty when the response has none (e.g. from a proxy)
+	Message    string
+	Violations []StringViolation // Characters at fault when str1 or str2 hold unsafe ones
+}
+
+func (e *APIError) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("fizzbuzz-api: status %d: %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("fizzbuzz-api: status %d: %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+func (e *APIError) Unwrap() error {
+	return codeErrors[e.Code]
+}
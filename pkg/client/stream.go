@@ -0,0 +1,54 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+)
+
+// GenerateStream returns the sequence of the request as values are received, without holding it in memory.
+// Iteration stops at the first error, which is yielded with an empty value; stopping early closes the connection.
+//
+//	for value, err := range c.GenerateStream(ctx, req) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(value)
+//	}
+func (c *Client) GenerateStream(ctx context.Context, req FizzBuzzRequest) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		resp, err := c.do(ctx, http.MethodPost, "/fizzbuzz/generate/stream", nil, req)
+		if err != nil {
+			yield("", err)
+			return
+		}
+		defer resp.Body.Close()
+
+		dec := json.NewDecoder(resp.Body)
+		expected := req.length()
+		var received uint64
+		for {
+			var value string
+			err := dec.Decode(&value)
+			if errors.Is(err, io.EOF) {
+				// The server ends the response early when it fails after streaming started
+				if received < expected {
+					yield("", fmt.Errorf("fizzbuzz-api: stream ended after %d of %d values: %w", received, expected, io.ErrUnexpectedEOF))
+				}
+				return
+			}
+			if err != nil {
+				yield("", fmt.Errorf("fizzbuzz-api: invalid stream: %w", err))
+				return
+			}
+			received++
+			if !yield(value, nil) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,311 @@
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"iter"
+	"math/big"
+	"strconv"
+)
+
+// The types below mirror the JSON bodies of the API, see internal/fizzbuzzapi/types
+
+// FizzBuzzRequest asks for the values from 1 to Limit, or for a range from Start to End by Step, replacing Limit.
+// Ranges may be negative or descending, see the Range helper.
+type FizzBuzzRequest struct {
+	Int1  int    `json:"int1"`
+	Int2  int    `json:"int2"`
+	Limit int    `json:"limit,omitempty"`
+	Start *int   `json:"start,omitempty"`
+	End   *int   `json:"end,omitempty"`
+	Step  *int   `json:"step,omitempty"` // 1 by default, -1 when End is below Start
+	Str1  string `json:"str1"`           // May insert the number it replaces: "fizz#{n}", "{n:hex}"
+	Str2  string `json:"str2"`
+
+	NumberFormat string `json:"number_format,omitempty"` // decimal by default, hex, binary, roman, words, or a zero-padded width like 05
+}
+
+// Range returns a copy of the request asking for the values from start to end by step, e.g. Range(-50, 50, 1)
+func (r FizzBuzzRequest) Range(start, end, step int) FizzBuzzRequest {
+	r.Limit, r.Start, r.End, r.Step = 0, &start, &end, &step
+	return r
+}
+
+// length returns the number of values the request asks for, mirroring the defaults of the server:
+// a range starts at 1, ends at its start, and steps by 1 towards its end. Invalid ranges have no value.
+func (r FizzBuzzRequest) length() uint64 {
+	if r.Start == nil && r.End == nil && r.Step == nil {
+		return uint64(max(r.Limit, 0))
+	}
+	start := 1
+	if r.Start != nil {
+		start = *r.Start
+	}
+	end := start
+	if r.End != nil {
+		end = *r.End
+	}
+	step := 1
+	if r.Step != nil {
+		step = *r.Step
+	} else if end < start {
+		step = -1
+	}
+
+	// Computed on uint64, as the distance between two ints may overflow int
+	switch {
+	case step > 0 && end >= start:
+		return (uint64(end)-uint64(start))/uint64(step) + 1
+	case step < 0 && end <= start:
+		return (uint64(start)-uint64(end))/-uint64(step) + 1
+	default:
+		return 0
+	}
+}
+
+type FizzBuzzResponse struct {
+	Result   []string `json:"result"`
+	Duration int64    `json:"duration_ms"`
+}
+
+// FizzBuzzCompactResponse describes a sequence by the pattern repeating every lcm(int1, int2) positions.
+// Expand or At decode it without the server materializing the values.
+type FizzBuzzCompactResponse struct {
+	Limit    int    `json:"limit"` // Number of values of the sequence
+	Start    int    `json:"start"` // Value at position 1
+	Step     int    `json:"step"`  // Difference between consecutive values
+	Period   int    `json:"period"`
+	Pattern  string `json:"pattern"` // One character per position of the period: "." number, "1" str1, "2" str2, "3" str1str2
+	Str1     string `json:"str1"`
+	Str2     string `json:"str2"`
+	Duration int64  `json:"duration_ms"`
+}
+
+// At returns the value at position i of the sequence, from 1 to Limit. Positions outside the sequence,
+// such as any position of an empty one, return "".
+func (r FizzBuzzCompactResponse) At(i int) string {
+	if i < 1 || i > r.Limit || r.Period <= 0 {
+		return ""
+	}
+	if r.Step == 0 {
+		r.Start, r.Step = 1, 1 // Servers predating ranges
+	}
+	switch r.Pattern[(i-1)%r.Period] {
+	case '1':
+		return r.Str1
+	case '2':
+		return r.Str2
+	case '3':
+		return r.Str1 + r.Str2
+	default:
+		return strconv.Itoa(r.Start + (i-1)*r.Step)
+	}
+}
+
+// Expand returns the values of the sequence, decoded as they are consumed
+func (r FizzBuzzCompactResponse) Expand() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for i := 1; i <= r.Limit; i++ {
+			if !yield(r.At(i)) {
+				return
+			}
+		}
+	}
+}
+
+// FizzBuzzExplainResponse holds the values of a sequence along with the rules that produced them
+type FizzBuzzExplainResponse struct {
+	Result   []FizzBuzzExplainedValue `json:"result"`
+	Summary  FizzBuzzExplainSummary   `json:"summary"`
+	Duration int64                    `json:"duration_ms"`
+}
+
+type FizzBuzzExplainedValue struct {
+	Index  int      `json:"index"`  // Position in the sequence, from 1
+	Number int      `json:"number"` // Number at that position, before replacement
+	Rules  []string `json:"rules"`  // "int1" and "int2" when they divide the number
+	Token  string   `json:"token"`  // "number", "str1", "str2" or "str1str2"
+	Value  string   `json:"value"`
+}
+
+type FizzBuzzExplainSummary struct {
+	Period           int            `json:"period"`            // Positions after which the matched rules repeat
+	Counts           map[string]int `json:"counts"`            // Number of values of each token
+	FirstOccurrences map[string]int `json:"first_occurrences"` // Index of the first value of each token found
+}
+
+// FizzBuzzVerifyResponse grades a submitted sequence
+type FizzBuzzVerifyResponse struct {
+	Pass          bool              `json:"pass"`
+	Expected      int               `json:"expected"`   // Number of values of the sequence
+	Submitted     int               `json:"submitted"`  // Number of values submitted
+	Mismatches    int               `json:"mismatches"` // Positions whose value differs, is missing or is extra
+	FirstMismatch *FizzBuzzMismatch `json:"first_mismatch,omitempty"`
+	Diff          FizzBuzzDiff      `json:"diff"`
+	Duration      int64             `json:"duration_ms"`
+}
+
+type FizzBuzzMismatch struct {
+	Index    int     `json:"index"`
+	Expected *string `json:"expected,omitempty"` // Nil past the end of the sequence
+	Actual   *string `json:"actual,omitempty"`   // Nil past the end of the submission
+}
+
+type FizzBuzzDiff struct {
+	Changed int                `json:"changed"`
+	Missing int                `json:"missing"`
+	Extra   int                `json:"extra"`
+	ByToken map[string]int     `json:"by_token"` // Changed and missing values by expected token
+	Hunks   []FizzBuzzDiffHunk `json:"hunks"`    // The first runs of consecutive mismatching positions
+}
+
+type FizzBuzzDiffHunk struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// FizzBuzzInferResponse lists the requests producing an observed sequence. When Possible is false, Conflict
+// tells the first value no rule set explains.
+type FizzBuzzInferResponse struct {
+	Limit      int                      `json:"limit"`
+	Possible   bool                     `json:"possible"`
+	Ambiguous  bool                     `json:"ambiguous"` // Several candidates, or unbounded divisors
+	Candidates []FizzBuzzInferCandidate `json:"candidates"`
+	Truncated  bool                     `json:"truncated,omitempty"`
+	Rules      []FizzBuzzRule           `json:"rules,omitempty"` // Set when no request produces the sequence, in ascending divisor order
+	Conflict   *FizzBuzzInferConflict   `json:"conflict,omitempty"`
+	Duration   int64                    `json:"duration_ms"`
+}
+
+type FizzBuzzInferCandidate struct {
+	Int1      int      `json:"int1"`
+	Int2      int      `json:"int2"`
+	Str1      string   `json:"str1,omitempty"`
+	Str2      string   `json:"str2,omitempty"`
+	Limit     int      `json:"limit"`
+	Unbounded []string `json:"unbounded,omitempty"` // "int1", "int2": any integer above Limit, given as Limit + 1
+}
+
+type FizzBuzzRule struct {
+	Divisor int    `json:"divisor"`
+	Str     string `json:"str"`
+}
+
+type FizzBuzzInferConflict struct {
+	Index    int    `json:"index"`
+	Value    string `json:"value"`
+	Expected string `json:"expected"`
+	Reason   string `json:"reason"` // "unexpected_number" or "unexpected_value"
+}
+
+// BigInt is an integer of any size, carried as a decimal string in JSON bodies
+type BigInt struct{ big.Int }
+
+func (n BigInt) MarshalJSON() ([]byte, error) {
+	return strconv.AppendQuote(nil, n.String()), nil
+}
+
+// UnmarshalJSON accepts decimal strings and JSON numbers
+func (n *BigInt) UnmarshalJSON(b []byte) error {
+	if s, err := strconv.Unquote(string(b)); err == nil {
+		b = []byte(s)
+	}
+	if _, ok := n.SetString(string(bytes.TrimSpace(b)), 10); !ok {
+		return fmt.Errorf("invalid integer %s", b)
+	}
+	return nil
+}
+
+// FizzBuzzAtRequest asks for the value at position N of a sequence, N may go beyond the max limit of the server and int64
+type FizzBuzzAtRequest struct {
+	N    *big.Int
+	Int1 int64
+	Int2 int64
+	Str1 string
+	Str2 string
+}
+
+type FizzBuzzAtResponse struct {
+	N     BigInt `json:"n"`
+	Value string `json:"value"`
+}
+
+// FizzBuzzCountRequest asks how many positions up to Limit hold each kind of value, Limit may go beyond int64
+type FizzBuzzCountRequest struct {
+	Limit *big.Int
+	Int1  int64
+	Int2  int64
+}
+
+type FizzBuzzCountResponse struct {
+	Limit    BigInt `json:"limit"`
+	Numbers  BigInt `json:"numbers"`
+	Str1     BigInt `json:"str1"`
+	Str2     BigInt `json:"str2"`
+	Str1Str2 BigInt `json:"str1str2"`
+}
+
+// FizzBuzzWindowRequest asks for Size values from position Start, Start may go beyond int64 but Size is bound by the max limit
+type FizzBuzzWindowRequest struct {
+	Start *big.Int
+	Size  int
+	Int1  int64
+	Int2  int64
+	Str1  string
+	Str2  string
+}
+
+type FizzBuzzWindowResponse struct {
+	Start  BigInt   `json:"start"`
+	Result []string `json:"result"`
+}
+
+type FizzBuzzStats struct {
+	MostFrequentRequests []FizzBuzzRequest   `json:"most_frequent_request"`
+	Count                int                 `json:"count"`
+	Variants             [][]FizzBuzzRequest `json:"variants,omitempty"` // Observed requests per most frequent request, unless keyed by exact request
+}
+
+type FizzBuzzStatsSummary struct {
+	TotalRequests   int                   `json:"total_requests"`
+	UniqueRequests  int                   `json:"unique_requests"`
+	ErrorRequests   map[string]int        `json:"error_requests"`
+	LimitHistogram  []FizzBuzzLimitBucket `json:"limit_histogram"`
+	TopDivisorPairs []FizzBuzzDivisorPair `json:"top_divisor_pairs"`
+	TopStringPairs  []FizzBuzzStringPair  `json:"top_string_pairs"`
+}
+
+type FizzBuzzLimitBucket struct {
+	Min   int `json:"min"`
+	Max   int `json:"max"`
+	Count int `json:"count"`
+}
+
+type FizzBuzzDivisorPair struct {
+	Int1  int `json:"int1"`
+	Int2  int `json:"int2"`
+	Count int `json:"count"`
+}
+
+type FizzBuzzStringPair struct {
+	Str1  string `json:"str1"`
+	Str2  string `json:"str2"`
+	Count int    `json:"count"`
+}
+
+// StringViolation is a character str1 or str2 may not hold: a control, bidi or zero-width character
+type StringViolation struct {
+	Field     string `json:"field"`     // "str1" or "str2"
+	Offset    int    `json:"offset"`    // In bytes
+	Character string `json:"character"` // Code point, as U+202E
+	Reason    string `json:"reason"`    // "control", "bidi" or "zero_width"
+}
+
+// FizzBuzzStatsFilter selects the stats entries removed by ResetStats. Nil fields match any value.
+type FizzBuzzStatsFilter struct {
+	Int1  *int
+	Int2  *int
+	Limit *int
+	Str1  *string
+	Str2  *string
+}